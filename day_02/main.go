@@ -6,6 +6,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
 )
 
 // ----------------------- Program Struct Start -----------------------
@@ -71,9 +73,25 @@ func (program *Program) reset_codes() {
 	copy(program.codes, program.reset)
 }
 
+// find_param solves for the noun and verb symbolically, and only tries every
+// pair when the result does not depend on them linearly.
 func (program *Program) find_param(target_value int) (int, int) {
 	var limit int = len(program.codes)
 
+	state, err := intcode.Evaluate(program.reset, map[int]string{1: "noun", 2: "verb"})
+	if err == nil {
+		var result *intcode.Expression = state.Cell(0)
+		linear, is_linear := result.Linear()
+		if is_linear {
+			fmt.Println("Result: '", linear, "' using cells", result.Cells)
+			values, found := linear.Solve(target_value, intcode.Range{Symbol: "noun", Low: 0, High: limit}, intcode.Range{Symbol: "verb", Low: 0, High: limit})
+			if !found {
+				return -1, -1
+			}
+			return values["noun"], values["verb"]
+		}
+	}
+
 	for noun := 0; noun < limit; noun++ {
 		for verb := 0; verb < limit; verb++ {
 			program.reset_codes()
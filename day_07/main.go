@@ -2,316 +2,258 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"runtime"
+	"slices"
 	"strconv"
 	"strings"
-)
-
-// ----------------------- Opcode Struct Start -----------------------
-
-type Opcode struct {
-	opcode int
-	tags   []int
-}
+	"sync"
 
-func get_opcode(value int) Opcode {
-	var opcode int = value % 100
-	var tags []int = make([]int, 0)
-	value = value / 100
-
-	for value != 0 {
-		var tag int = value % 10
-		// Only valid tags
-		if tag != 1 && tag != 0 {
-			fmt.Printf("Tag value not recognized")
-			os.Exit(1)
-		}
-
-		tags = append(tags, tag)
-		value = value / 10
-	}
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
 
-	// Only valid opcodes
-	if (opcode < 1 || opcode > 8) && opcode != 99 {
-		fmt.Printf("Tag value not recognized")
-		os.Exit(1)
-	}
+// ----------------------- Permutation Iterator Struct Start -----------------------
 
-	return Opcode{opcode, tags}
+// permutation_iterator walks every ordering of a list with Heap's algorithm,
+// one swap at a time, so no more than one ordering is ever held.
+type permutation_iterator struct {
+	values  []int
+	counter []int
+	index   int
+	started bool
 }
 
-// ----------------------- Opcode Struct End -----------------------
-
-// ----------------------- IntCode Computer Struct Start -----------------------
-
-type IntCodeComputer struct {
-	state          string
-	input          []int
-	input_pointer  int
-	memory         []int
-	memory_pointer int
-	output         []int
+func new_permutation_iterator(values []int) *permutation_iterator {
+	var copied []int = make([]int, len(values))
+	copy(copied, values)
+	return &permutation_iterator{copied, make([]int, len(values)), 1, false}
 }
 
-func (computer *IntCodeComputer) transform_to_arguments(opcode Opcode, number_arg int, writing_args int) []int {
-	var arguments []int = make([]int, 0, number_arg)
-	// Iterate over arguments
-	for arg_index := 0; arg_index < number_arg; arg_index++ {
-
-		var type_argument int
-		if arg_index >= len(opcode.tags) {
-			// By omission type is 0
-			type_argument = 0
-		} else {
-			// Else type is given by opcode tag
-			type_argument = opcode.tags[arg_index]
-		}
-
-		var argument int = computer.memory[computer.memory_pointer+1+arg_index]
-
-		if arg_index >= number_arg-writing_args || type_argument == 1 {
-			// Immediate Mode or Writing Arg
-			arguments = append(arguments, argument)
-		} else if type_argument == 0 {
-			// Position Mode
-			var argument_value int = computer.memory[argument]
-			arguments = append(arguments, argument_value)
-		}
+// next returns a copy of the following ordering, or false once all were
+// returned.
+func (iterator *permutation_iterator) next() ([]int, bool) {
+	if !iterator.started {
+		iterator.started = true
+		return slices.Clone(iterator.values), true
 	}
 
-	return arguments
-}
-
-func (computer *IntCodeComputer) run() {
-	computer.state = "running"
-	for computer.state == "running" {
-		var current_opcode Opcode = get_opcode(computer.memory[computer.memory_pointer])
-		switch current_opcode.opcode {
-		// Halting
-		case 99:
-			computer.state = "halted"
-
-		// Addition
-		case 1:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.memory[arguments[2]] = arguments[0] + arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Multiplication
-		case 2:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.memory[arguments[2]] = arguments[0] * arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Input
-		case 3:
-			if computer.input_pointer >= len(computer.input) {
-				// Computer must wait for more input
-				computer.state = "paused"
-			} else {
-				var arguments []int = computer.transform_to_arguments(current_opcode, 1, 1)
-				computer.memory[arguments[0]] = computer.input[computer.input_pointer]
-
-				//Advance pointers
-				computer.memory_pointer = computer.memory_pointer + 2
-				computer.input_pointer = computer.input_pointer + 1
-			}
-
-		// Output
-		case 4:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 1)
-			computer.output = append(computer.output, computer.memory[arguments[0]])
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-
-		// Jump if True
-		case 5:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] != 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Jump if False
-		case 6:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] == 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Less than
-		case 7:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			if arguments[0] < arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
+	for iterator.index < len(iterator.values) {
+		var index int = iterator.index
+		if iterator.counter[index] < index {
+			var swap_index int = 0
+			if index%2 == 1 {
+				swap_index = iterator.counter[index]
 			}
+			iterator.values[swap_index], iterator.values[index] = iterator.values[index], iterator.values[swap_index]
 
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Equals
-		case 8:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			if arguments[0] == arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Should not happen
-		default:
-			fmt.Println("Code not recognized")
-			os.Exit(1)
+			iterator.counter[index] = iterator.counter[index] + 1
+			iterator.index = 1
+			return slices.Clone(iterator.values), true
 		}
+
+		iterator.counter[index] = 0
+		iterator.index = iterator.index + 1
 	}
+	return nil, false
 }
 
-// ----------------------- IntCode Computer Struct End -----------------------
+// ----------------------- Permutation Iterator Struct End -----------------------
 
-// ----------------------- Amplifier Controller Struct End -----------------------
+// ----------------------- Amplifier Controller Struct Start -----------------------
 
 type AmplifierController struct {
-	number_amplifiers int
-	minimum_phase     int
-	maximum_phase     int
-	first_input       int
-	code              []int
+	topology      Topology
+	minimum_phase int
+	maximum_phase int
+	first_input   int
+	code          []int
 }
 
-func (controller *AmplifierController) run_with_phase(phase_setting []int) int {
-	var current_input = controller.first_input
-	for _, phase_value := range phase_setting {
-		var inputs []int = make([]int, 0, 2)
-		inputs = append(inputs, phase_value)
-		inputs = append(inputs, current_input)
-		var memory []int = make([]int, 0)
-		memory = append(memory, controller.code...)
-		var output []int = make([]int, 0)
-
-		var computer IntCodeComputer = IntCodeComputer{"booting", inputs, 0, memory, 0, output}
-		computer.run()
-		current_input = computer.output[len(computer.output)-1]
-	}
+// MaximumThrust is the highest thrust found and every phase setting reaching
+// it, in the order they were generated.
+type MaximumThrust struct {
+	thrust         int
+	phase_settings [][]int
+}
 
-	return current_input
+type phase_job struct {
+	index         int
+	phase_setting []int
 }
 
-func (controller *AmplifierController) run_with_phase_with_feedback(phase_setting []int) int {
-	var amplifiers []IntCodeComputer = make([]IntCodeComputer, 0, 6)
+type phase_result struct {
+	index         int
+	phase_setting []int
+}
 
-	// Setup amplifiers
-	for _, phase_value := range phase_setting {
-		var inputs []int = make([]int, 0)
-		inputs = append(inputs, phase_value)
-		var memory []int = make([]int, 0)
-		memory = append(memory, controller.code...)
-		var output []int = make([]int, 0)
+// thrust_worker keeps the best settings among those it evaluated.
+type thrust_worker struct {
+	thrust  int
+	results []phase_result
+	err     error
+}
 
-		var computer IntCodeComputer = IntCodeComputer{"booting", inputs, 0, memory, 0, output}
-		amplifiers = append(amplifiers, computer)
+func (controller *AmplifierController) run_with_phase(template intcode.IntCodeComputer, phase_setting []int) (int, error) {
+	run, err := new_topology_run(controller.topology, template, phase_setting)
+	if err != nil {
+		return 0, err
 	}
+	return run.thrust(controller.first_input, controller.topology.Output)
+}
 
-	// Run amplifiers
-	var current_input = controller.first_input
-	var halted bool = false
-
-	for !halted {
-		for index, _ := range amplifiers {
-			// Retrieve amplifier
-			amplifier := amplifiers[index]
-			amplifier.input = append(amplifier.input, current_input)
+func (controller *AmplifierController) evaluate(ctx context.Context, jobs <-chan phase_job, worker *thrust_worker, cancel context.CancelFunc) {
+	// Amplifiers are copied from a computer of the worker's own, as copies
+	// share memory pages with it
+	var template intcode.IntCodeComputer = intcode.NewFromCodes(controller.code)
 
-			amplifier.run()
-			current_input = amplifier.output[len(amplifier.output)-1]
+	for job := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
 
-			halted = halted || amplifier.state == "halted"
-			// Save new version of amplifier
-			amplifiers[index] = amplifier
+		thrust_value, err := controller.run_with_phase(template, job.phase_setting)
+		if err != nil {
+			worker.err = fmt.Errorf("phase setting %v: %w", job.phase_setting, err)
+			cancel()
+			return
 		}
 
+		if len(worker.results) == 0 || thrust_value > worker.thrust {
+			worker.thrust = thrust_value
+			worker.results = []phase_result{{job.index, job.phase_setting}}
+		} else if thrust_value == worker.thrust {
+			worker.results = append(worker.results, phase_result{job.index, job.phase_setting})
+		}
 	}
-
-	return current_input
 }
 
-func (controller *AmplifierController) get_maximum_thrust(with_feedback bool) ([]int, int) {
+// get_maximum_thrust tries every ordering of the phase range on a pool of
+// workers, each running its own amplifiers. When ctx is cancelled it stops
+// early, returning the best found so far along with the context's error. The
+// first phase setting the amplifiers fail on stops it too, returning only
+// that failure.
+func (controller *AmplifierController) get_maximum_thrust(ctx context.Context, number_workers int) (MaximumThrust, error) {
 	var phase_setting []int = make([]int, 0)
 	// Initialize phase setting
 	for i := controller.minimum_phase; i <= controller.maximum_phase; i++ {
 		phase_setting = append(phase_setting, i)
 	}
 
-	// Storing of maximums
-	var maximum_thrust int = 0
-	var maximum_phase_setting []int = make([]int, 0)
+	search_ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var jobs chan phase_job = make(chan phase_job, number_workers)
+	var workers []*thrust_worker = make([]*thrust_worker, number_workers)
+	var group sync.WaitGroup
+	for index := range workers {
+		workers[index] = &thrust_worker{}
+		group.Add(1)
+		go func(worker *thrust_worker) {
+			defer group.Done()
+			controller.evaluate(search_ctx, jobs, worker, cancel)
+		}(workers[index])
+	}
 
-	var permutations [][]int = permutations(phase_setting)
-	for _, permutation := range permutations {
+	// Hand out orderings as they are generated
+	var iterator *permutation_iterator = new_permutation_iterator(phase_setting)
+	for index := 0; search_ctx.Err() == nil; index++ {
+		permutation, has_next := iterator.next()
+		if !has_next {
+			break
+		}
 
-		// Run Intcode
-		var thrust_value int
-		if !with_feedback {
-			thrust_value = controller.run_with_phase(permutation)
-		} else {
-			thrust_value = controller.run_with_phase_with_feedback(permutation)
+		select {
+		case jobs <- phase_job{index, permutation}:
+		case <-search_ctx.Done():
+		}
+	}
+	close(jobs)
+	group.Wait()
+
+	// Gather the best of every worker
+	var maximum MaximumThrust = MaximumThrust{0, make([][]int, 0)}
+	var results []phase_result = make([]phase_result, 0)
+	for _, worker := range workers {
+		if worker.err != nil {
+			return MaximumThrust{}, worker.err
+		}
+		if len(worker.results) == 0 {
+			continue
 		}
 
-		// Check if maximum
-		if thrust_value > maximum_thrust {
-			maximum_thrust = thrust_value
-			maximum_phase_setting = permutation
+		if len(results) == 0 || worker.thrust > maximum.thrust {
+			maximum.thrust = worker.thrust
+			results = slices.Clone(worker.results)
+		} else if worker.thrust == maximum.thrust {
+			results = append(results, worker.results...)
 		}
 	}
 
-	return maximum_phase_setting, maximum_thrust
+	slices.SortFunc(results, func(first phase_result, second phase_result) int {
+		return first.index - second.index
+	})
+	for _, result := range results {
+		maximum.phase_settings = append(maximum.phase_settings, result.phase_setting)
+	}
+	return maximum, ctx.Err()
 }
 
 // ----------------------- Amplifier Controller Struct End -----------------------
 
-func permutations(arr []int) [][]int {
-	var helper func([]int, int)
-	res := [][]int{}
-
-	helper = func(arr []int, n int) {
-		if n == 1 {
-			tmp := make([]int, len(arr))
-			copy(tmp, arr)
-			res = append(res, tmp)
-		} else {
-			for i := 0; i < n; i++ {
-				helper(arr, n-1)
-				if n%2 == 1 {
-					tmp := arr[i]
-					arr[i] = arr[n-1]
-					arr[n-1] = tmp
-				} else {
-					tmp := arr[0]
-					arr[0] = arr[n-1]
-					arr[n-1] = tmp
-				}
-			}
-		}
+// topology_from_argument builds a named topology sized to the phase range, or
+// loads one from a file.
+func topology_from_argument(argument string, number_amplifiers int) (Topology, error) {
+	switch argument {
+	case "chain":
+		return chain_topology(number_amplifiers), nil
+	case "feedback":
+		return feedback_topology(number_amplifiers), nil
+	case "fan":
+		return fan_topology(number_amplifiers-1, "sum"), nil
+	case "nested":
+		return nested_loop_topology(number_amplifiers), nil
+	default:
+		return load_topology(argument)
+	}
+}
+
+func print_maximum_thrust(ctx context.Context, controller AmplifierController, message string) {
+	maximum, err := controller.get_maximum_thrust(ctx, runtime.GOMAXPROCS(0))
+	// A failing phase setting leaves no answer, and neither does stopping
+	// before any setting was tried
+	if err != nil && (ctx.Err() == nil || len(maximum.phase_settings) == 0) {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	for _, phase_setting := range maximum.phase_settings {
+		fmt.Println(phase_setting)
+	}
+	fmt.Printf(message, maximum.thrust)
+	if err != nil {
+		fmt.Printf("Search stopped early, the thrust is only the best found: %v\n", err)
+		os.Exit(1)
 	}
-	helper(arr, len(arr))
-	return res
 }
 
+// Finds the best phase settings for a chain and for a feedback loop of five
+// amplifiers, and then for any other topology given with its phase range.
+// Chains, feedback loops, fans and nested loops have as many amplifiers as
+// phases:
+//
+//	go run . feedback 5 14
+//	go run . fan 0 4
+//	go run . nested 5 9
+//	go run . topology.json 5 9
 func main() {
 
+	// Interrupting stops a search, showing the best found so far
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	// ----------------- SETUP INPUT TXT -----------------
 	// Trying to open file
 	file, _ := os.Open("input.txt")
@@ -323,26 +265,35 @@ func main() {
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 
-		// Iterate over split
-		var split []string = strings.Split(scanner.Text(), ",")
-		var values_converted []int = make([]int, 0)
-		for _, code := range split {
-			code_converted, _ := strconv.Atoi(code)
-			values_converted = append(values_converted, code_converted)
-		}
+		values_converted, _ := intcode.Parse(scanner.Text())
 
 		// Part 1
-		var amplifier_controller AmplifierController = AmplifierController{5, 0, 4, 0, values_converted}
-		phase_setting, thrust_value := amplifier_controller.get_maximum_thrust(false)
-		fmt.Println(phase_setting)
-		fmt.Printf("Maximum thrust possible without feedback: ' %d ' (part 1)\n", thrust_value)
+		var amplifier_controller AmplifierController = AmplifierController{chain_topology(5), 0, 4, 0, values_converted}
+		print_maximum_thrust(ctx, amplifier_controller, "Maximum thrust possible without feedback: ' %d ' (part 1)\n")
 
 		fmt.Println("------------------------------------------")
 
 		// Part 2
-		var feedback_amplifier_controller AmplifierController = AmplifierController{5, 5, 9, 0, values_converted}
-		phase_setting, thrust_value = feedback_amplifier_controller.get_maximum_thrust(true)
-		fmt.Println(phase_setting)
-		fmt.Printf("Maximum thrust possible with feedback: ' %d ' (part 2)\n", thrust_value)
+		var feedback_amplifier_controller AmplifierController = AmplifierController{feedback_topology(5), 5, 9, 0, values_converted}
+		print_maximum_thrust(ctx, feedback_amplifier_controller, "Maximum thrust possible with feedback: ' %d ' (part 2)\n")
+
+		// Any other topology asked for
+		if len(os.Args) > 3 {
+			minimum_phase, err_minimum := strconv.Atoi(os.Args[2])
+			maximum_phase, err_maximum := strconv.Atoi(os.Args[3])
+			if err_minimum != nil || err_maximum != nil {
+				fmt.Printf("Phase range not recognized: ' %s ' ' %s '\n", os.Args[2], os.Args[3])
+				os.Exit(1)
+			}
+			topology, err := topology_from_argument(os.Args[1], maximum_phase-minimum_phase+1)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			fmt.Println("------------------------------------------")
+			var topology_controller AmplifierController = AmplifierController{topology, minimum_phase, maximum_phase, 0, values_converted}
+			print_maximum_thrust(ctx, topology_controller, "Maximum thrust possible with ' "+strings.ReplaceAll(os.Args[1], "%", "%%")+" ': ' %d '\n")
+		}
 	}
 }
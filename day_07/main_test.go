@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+func TestPermutationIterator(t *testing.T) {
+	var values []int = []int{5, 6, 7, 8, 9}
+	var iterator *permutation_iterator = new_permutation_iterator(values)
+
+	var seen map[string]bool = make(map[string]bool)
+	for permutation, has_next := iterator.next(); has_next; permutation, has_next = iterator.next() {
+		var sorted []int = slices.Clone(permutation)
+		slices.Sort(sorted)
+		if !slices.Equal(sorted, values) {
+			t.Fatalf("%v is no ordering of %v", permutation, values)
+		}
+		seen[fmt.Sprint(permutation)] = true
+	}
+
+	if len(seen) != 120 {
+		t.Fatalf("%d distinct orderings, expected 120", len(seen))
+	}
+	if !slices.Equal(values, []int{5, 6, 7, 8, 9}) {
+		t.Fatalf("orderings changed the values to %v", values)
+	}
+}
+
+// Reads the phase and an input and outputs their product, so every ordering
+// of a chain gives the same thrust
+func product_controller(t *testing.T, number_amplifiers int) AmplifierController {
+	codes, err := intcode.Parse("3,11,3,12,2,11,12,12,4,12,99,0,0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return AmplifierController{chain_topology(number_amplifiers), 1, number_amplifiers, 1, codes}
+}
+
+func TestMaximumThrustTies(t *testing.T) {
+	var controller AmplifierController = product_controller(t, 4)
+	maximum, err := controller.get_maximum_thrust(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if maximum.thrust != 24 || len(maximum.phase_settings) != 24 {
+		t.Fatalf("thrust ' %d ' for %d settings, expected ' 24 ' for every one of 24", maximum.thrust, len(maximum.phase_settings))
+	}
+	if !slices.Equal(maximum.phase_settings[0], []int{1, 2, 3, 4}) {
+		t.Fatalf("first setting %v, expected the first generated", maximum.phase_settings[0])
+	}
+}
+
+func TestMaximumThrustCancelled(t *testing.T) {
+	// Ten amplifiers have millions of orderings
+	var controller AmplifierController = product_controller(t, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	maximum, err := controller.get_maximum_thrust(ctx, 2)
+	if !errors.Is(err, context.Canceled) || len(maximum.phase_settings) != 0 {
+		t.Fatalf("cancelled search gave %v with ' %v '", maximum, err)
+	}
+
+	// A combiner cancelling the search once a setting reaches it stops the
+	// search with that setting's thrust as the best so far
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	Combiners["cancel"] = func(values []int) int {
+		cancel()
+		return Combiners["product"](values)
+	}
+	defer delete(Combiners, "cancel")
+	controller.topology = fan_topology(9, "cancel")
+
+	maximum, err = controller.get_maximum_thrust(ctx, 2)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("search stopped with ' %v '", err)
+	}
+	if maximum.thrust != 3628800 || len(maximum.phase_settings) == 0 || len(maximum.phase_settings) > 2 {
+		t.Fatalf("best so far ' %d ' for %d settings", maximum.thrust, len(maximum.phase_settings))
+	}
+}
+
+func TestMaximumThrustFailing(t *testing.T) {
+	// Phases past 4 make the amplifier jump off its program
+	codes, _ := intcode.Parse("3,0,1005,0,6,99,42")
+	var controller AmplifierController = AmplifierController{chain_topology(3), 0, 2, 0, codes}
+	maximum, err := controller.get_maximum_thrust(context.Background(), 2)
+	if err == nil || !strings.Contains(err.Error(), "phase setting") || len(maximum.phase_settings) != 0 {
+		t.Fatalf("failing search gave %v with ' %v '", maximum, err)
+	}
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// ----------------------- Topology Struct Start -----------------------
+
+// Name of the controller's first input among stage inputs
+const TOPOLOGY_INPUT string = "input"
+
+// Combiners merge the values reaching a fan in stage, one from each of its
+// inputs
+var Combiners map[string]func(values []int) int = map[string]func(values []int) int{
+	"sum": func(values []int) int {
+		var result int = 0
+		for _, value := range values {
+			result = result + value
+		}
+		return result
+	},
+	"product": func(values []int) int {
+		var result int = 1
+		for _, value := range values {
+			result = result * value
+		}
+		return result
+	},
+	"max": func(values []int) int {
+		var result int = values[0]
+		for _, value := range values[1:] {
+			result = max(result, value)
+		}
+		return result
+	},
+	"min": func(values []int) int {
+		var result int = values[0]
+		for _, value := range values[1:] {
+			result = min(result, value)
+		}
+		return result
+	},
+}
+
+// Stage is an amplifier, or a combiner when Combiner names one. An amplifier
+// reads its phase and then every value output by its inputs, in the order
+// they were output. A combiner waits for a value from each of its inputs and
+// outputs them combined. Every value output reaches every stage that lists
+// its producer as input, so inputs can form chains, fan out, fan in and loop.
+type Stage struct {
+	Name     string   `json:"name"`
+	Combiner string   `json:"combiner,omitempty"`
+	Inputs   []string `json:"inputs"`
+}
+
+// Topology wires stages together. Amplifiers take the phases of a setting in
+// the order they are listed, and the thrust is the last value output by the
+// Output stage.
+type Topology struct {
+	Stages []Stage `json:"stages"`
+	Output string  `json:"output"`
+}
+
+func amplifier_name(index int) string {
+	if index < 26 {
+		return string(rune('A' + index))
+	}
+	return fmt.Sprintf("A%d", index)
+}
+
+// chain_topology feeds every amplifier into the next.
+func chain_topology(number_amplifiers int) Topology {
+	var topology Topology = Topology{make([]Stage, 0, number_amplifiers), amplifier_name(number_amplifiers - 1)}
+
+	var previous string = TOPOLOGY_INPUT
+	for index := 0; index < number_amplifiers; index++ {
+		topology.Stages = append(topology.Stages, Stage{amplifier_name(index), "", []string{previous}})
+		previous = amplifier_name(index)
+	}
+	return topology
+}
+
+// feedback_topology is a chain whose last amplifier feeds back into the
+// first.
+func feedback_topology(number_amplifiers int) Topology {
+	var topology Topology = chain_topology(number_amplifiers)
+	topology.Stages[0].Inputs = append(topology.Stages[0].Inputs, topology.Output)
+	return topology
+}
+
+// fan_topology splits the input over parallel branches of amplifiers, joins
+// them with a combiner and amplifies the result once more.
+func fan_topology(branches int, combiner string) Topology {
+	var topology Topology = Topology{make([]Stage, 0, branches+2), amplifier_name(branches)}
+
+	var joined []string = make([]string, 0, branches)
+	for index := 0; index < branches; index++ {
+		topology.Stages = append(topology.Stages, Stage{amplifier_name(index), "", []string{TOPOLOGY_INPUT}})
+		joined = append(joined, amplifier_name(index))
+	}
+	topology.Stages = append(topology.Stages, Stage{combiner, combiner, joined})
+	topology.Stages = append(topology.Stages, Stage{amplifier_name(branches), "", []string{combiner}})
+	return topology
+}
+
+// nested_loop_topology is a feedback loop of amplifiers with a second loop
+// between the middle one and the one after it, when there are at least three.
+func nested_loop_topology(number_amplifiers int) Topology {
+	var topology Topology = feedback_topology(number_amplifiers)
+
+	var middle int = number_amplifiers / 2
+	if middle+1 < number_amplifiers {
+		topology.Stages[middle].Inputs = append(topology.Stages[middle].Inputs, amplifier_name(middle+1))
+	}
+	return topology
+}
+
+// load_topology reads a topology described in JSON, as in
+//
+//	{"stages": [
+//		{"name": "A", "inputs": ["input"]},
+//		{"name": "B", "inputs": ["input"]},
+//		{"name": "join", "combiner": "max", "inputs": ["A", "B"]},
+//		{"name": "C", "inputs": ["join", "C"]}
+//	], "output": "C"}
+func load_topology(file_name string) (Topology, error) {
+	content, err := os.ReadFile(file_name)
+	if err != nil {
+		return Topology{}, err
+	}
+
+	var topology Topology
+	err = json.Unmarshal(content, &topology)
+	if err != nil {
+		return Topology{}, err
+	}
+	return topology, topology.validate()
+}
+
+// Amplifiers returns the names of the amplifiers, in phase order.
+func (topology Topology) Amplifiers() []string {
+	var names []string = make([]string, 0, len(topology.Stages))
+	for _, stage := range topology.Stages {
+		if stage.Combiner == "" {
+			names = append(names, stage.Name)
+		}
+	}
+	return names
+}
+
+func (topology Topology) validate() error {
+	var names map[string]bool = map[string]bool{TOPOLOGY_INPUT: true}
+	for _, stage := range topology.Stages {
+		if names[stage.Name] {
+			return fmt.Errorf("stage name repeated: ' %s '", stage.Name)
+		}
+		names[stage.Name] = true
+	}
+
+	for _, stage := range topology.Stages {
+		if _, is_set := Combiners[stage.Combiner]; stage.Combiner != "" && !is_set {
+			return fmt.Errorf("combiner not recognized: ' %s '", stage.Combiner)
+		}
+		if len(stage.Inputs) == 0 {
+			return fmt.Errorf("stage without inputs: ' %s '", stage.Name)
+		}
+		for _, input := range stage.Inputs {
+			if !names[input] {
+				return fmt.Errorf("input of ' %s ' not recognized: ' %s '", stage.Name, input)
+			}
+		}
+	}
+
+	if !names[topology.Output] || topology.Output == TOPOLOGY_INPUT {
+		return fmt.Errorf("output stage not recognized: ' %s '", topology.Output)
+	}
+	return nil
+}
+
+// ----------------------- Topology Struct End -----------------------
+
+// ----------------------- Topology Run Struct Start -----------------------
+
+type stage_run struct {
+	stage     Stage
+	computer  *intcode.IntCodeComputer
+	pending   [][]int
+	last      int
+	has_value bool
+}
+
+// topology_run moves values between the stages of a topology, running one
+// amplifier at a time so the order values arrive in is always the same.
+type topology_run struct {
+	stages    []*stage_run
+	consumers map[string][]*stage_run
+}
+
+// new_topology_run gives every amplifier a copy of template.
+func new_topology_run(topology Topology, template intcode.IntCodeComputer, phase_setting []int) (*topology_run, error) {
+	err := topology.validate()
+	if err != nil {
+		return nil, err
+	}
+	if len(phase_setting) != len(topology.Amplifiers()) {
+		return nil, fmt.Errorf("phase setting has ' %d ' phases for ' %d ' amplifiers", len(phase_setting), len(topology.Amplifiers()))
+	}
+
+	var run *topology_run = &topology_run{make([]*stage_run, 0, len(topology.Stages)), make(map[string][]*stage_run)}
+	var phase_index int = 0
+	for _, stage := range topology.Stages {
+		var state *stage_run = &stage_run{stage, nil, make([][]int, len(stage.Inputs)), 0, false}
+		if stage.Combiner == "" {
+			var computer intcode.IntCodeComputer = intcode.MakeDeepCopy(template)
+			computer.AddInput(phase_setting[phase_index])
+			state.computer = &computer
+			phase_index = phase_index + 1
+		}
+
+		run.stages = append(run.stages, state)
+		for index, input := range stage.Inputs {
+			// Stages reading the same input twice are handed each value once
+			if !slices.Contains(stage.Inputs[:index], input) {
+				run.consumers[input] = append(run.consumers[input], state)
+			}
+		}
+	}
+
+	return run, nil
+}
+
+// emit hands a value output by a stage to every stage reading it.
+func (run *topology_run) emit(name string, value int) {
+	for _, consumer := range run.consumers[name] {
+		if consumer.computer != nil {
+			consumer.computer.AddInput(value)
+			continue
+		}
+
+		for index, input := range consumer.stage.Inputs {
+			if input == name {
+				consumer.pending[index] = append(consumer.pending[index], value)
+			}
+		}
+		run.combine(consumer)
+	}
+}
+
+func (run *topology_run) combine(state *stage_run) {
+	for {
+		for index := range state.pending {
+			if len(state.pending[index]) == 0 {
+				return
+			}
+		}
+
+		var values []int = make([]int, 0, len(state.pending))
+		for index := range state.pending {
+			values = append(values, state.pending[index][0])
+			state.pending[index] = state.pending[index][1:]
+		}
+
+		state.last, state.has_value = Combiners[state.stage.Combiner](values), true
+		run.emit(state.stage.Name, state.last)
+	}
+}
+
+// thrust runs amplifiers in order, each until it halts or waits for input,
+// for as long as any of them has input left, and returns the last value
+// output by the output stage.
+func (run *topology_run) thrust(first_input int, output string) (int, error) {
+	run.emit(TOPOLOGY_INPUT, first_input)
+
+	for progress := true; progress; {
+		progress = false
+		for _, state := range run.stages {
+			var computer *intcode.IntCodeComputer = state.computer
+			if computer == nil || computer.State() == intcode.Halted {
+				continue
+			}
+			if computer.State() == intcode.AwaitingInput && len(computer.PendingInput()) == 0 {
+				continue
+			}
+
+			progress = true
+			err := computer.Run()
+			if err != nil {
+				return 0, fmt.Errorf("amplifier ' %s ': %w", state.stage.Name, err)
+			}
+			for _, value := range computer.Output() {
+				state.last, state.has_value = value, true
+				run.emit(state.stage.Name, value)
+			}
+			computer.ClearOutput()
+		}
+	}
+
+	// Amplifiers still waiting never got all they needed
+	var waiting []string = make([]string, 0)
+	for _, state := range run.stages {
+		if state.computer != nil && state.computer.State() != intcode.Halted {
+			waiting = append(waiting, state.stage.Name)
+		}
+	}
+	if len(waiting) != 0 {
+		return 0, fmt.Errorf("amplifiers left waiting for input: %v", waiting)
+	}
+
+	for _, state := range run.stages {
+		if state.stage.Name == output && state.has_value {
+			return state.last, nil
+		}
+	}
+	return 0, fmt.Errorf("output stage ' %s ' never output", output)
+}
+
+// ----------------------- Topology Run Struct End -----------------------
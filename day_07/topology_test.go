@@ -0,0 +1,299 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// hand_wired_thrust runs the amplifiers as goroutines joined by channels, as
+// the solution did before topologies, the last one feeding back into the
+// first when there is feedback.
+func hand_wired_thrust(t *testing.T, codes []int, phase_setting []int, feedback bool) int {
+	var number_amplifiers int = len(phase_setting)
+
+	// Amplifier i reads from link i and writes to link i + 1
+	var links []chan int = make([]chan int, 0, number_amplifiers+1)
+	for _, phase_value := range phase_setting {
+		var link chan int = make(chan int, 2)
+		link <- phase_value
+		links = append(links, link)
+	}
+	links[0] <- 0
+	if feedback {
+		links = append(links, links[0])
+	} else {
+		links = append(links, make(chan int, 1))
+	}
+
+	var failures []error = make([]error, number_amplifiers)
+	var group sync.WaitGroup
+	for index := range phase_setting {
+		var computer intcode.IntCodeComputer = intcode.NewFromCodes(codes)
+		group.Add(1)
+		go func(index int) {
+			defer group.Done()
+			failures[index] = computer.RunWithChannels(links[index], links[index+1])
+		}(index)
+	}
+	group.Wait()
+
+	for _, err := range failures {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The last thrust is left unread once every amplifier stopped
+	var signal int = 0
+	for value := range links[number_amplifiers] {
+		signal = value
+	}
+	return signal
+}
+
+func TestTopologyExamples(t *testing.T) {
+	var tests []struct {
+		name          string
+		program       string
+		feedback      bool
+		phase_setting []int
+		thrust        int
+	} = []struct {
+		name          string
+		program       string
+		feedback      bool
+		phase_setting []int
+		thrust        int
+	}{
+		{
+			"chain 1", "3,15,3,16,1002,16,10,16,1,16,15,15,4,15,99,0,0",
+			false, []int{4, 3, 2, 1, 0}, 43210,
+		},
+		{
+			"chain 2", "3,23,3,24,1002,24,10,24,1002,23,-1,23,101,5,23,23,1,24,23,23,4,23,99,0,0",
+			false, []int{0, 1, 2, 3, 4}, 54321,
+		},
+		{
+			"chain 3", "3,31,3,32,1002,32,10,32,1001,31,-2,31,1007,31,0,33,1002,33,7,33,1,33,31,31,1,32,31,31,4,31,99,0,0,0",
+			false, []int{1, 0, 4, 3, 2}, 65210,
+		},
+		{
+			"feedback 1", "3,26,1001,26,-4,26,3,27,1002,27,2,27,1,27,26,27,4,27,1001,28,-1,28,1005,28,6,99,0,0,5",
+			true, []int{9, 8, 7, 6, 5}, 139629729,
+		},
+		{
+			"feedback 2", "3,52,1001,52,-5,52,3,53,1,52,56,54,1007,54,5,55,1005,55,26,1001,54,-5,54,1105,1,12,1,53,54,53,1008,54,0,55,1001,55,1,55,2,53,55,53,4,53,1001,56,-1,56,1005,56,6,99,0,0,0,0,10",
+			true, []int{9, 7, 8, 5, 6}, 18216,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			codes, err := intcode.Parse(test.program)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var topology Topology = chain_topology(5)
+			if test.feedback {
+				topology = feedback_topology(5)
+			}
+
+			if thrust := hand_wired_thrust(t, codes, test.phase_setting, test.feedback); thrust != test.thrust {
+				t.Fatalf("hand wired thrust ' %d ', expected ' %d '", thrust, test.thrust)
+			}
+			run, err := new_topology_run(topology, intcode.NewFromCodes(codes), test.phase_setting)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if thrust, err := run.thrust(0, topology.Output); err != nil || thrust != test.thrust {
+				t.Fatalf("topology thrust ' %d ' with ' %v ', expected ' %d '", thrust, err, test.thrust)
+			}
+
+			var controller AmplifierController = AmplifierController{topology, 0, 4, 0, codes}
+			if test.feedback {
+				controller.minimum_phase, controller.maximum_phase = 5, 9
+			}
+			maximum, err := controller.get_maximum_thrust(context.Background(), 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if maximum.thrust != test.thrust || !slices.ContainsFunc(maximum.phase_settings, func(setting []int) bool { return slices.Equal(setting, test.phase_setting) }) {
+				t.Fatalf("maximum ' %d ' at %v, expected ' %d ' at %v", maximum.thrust, maximum.phase_settings, test.thrust, test.phase_setting)
+			}
+		})
+	}
+}
+
+// Every ordering on the puzzle input gives the same thrust through a
+// topology as through the hand wired amplifiers.
+func TestTopologyMatchesHandWired(t *testing.T) {
+	content, err := os.ReadFile("input.txt")
+	if err != nil {
+		t.Skip(err)
+	}
+	codes, err := intcode.Parse(string(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, feedback := range []bool{false, true} {
+		var topology Topology = chain_topology(5)
+		var phases []int = []int{0, 1, 2, 3, 4}
+		if feedback {
+			topology = feedback_topology(5)
+			phases = []int{5, 6, 7, 8, 9}
+		}
+
+		var iterator *permutation_iterator = new_permutation_iterator(phases)
+		for phase_setting, has_next := iterator.next(); has_next; phase_setting, has_next = iterator.next() {
+			run, err := new_topology_run(topology, intcode.NewFromCodes(codes), phase_setting)
+			if err != nil {
+				t.Fatal(err)
+			}
+			thrust, err := run.thrust(0, topology.Output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := hand_wired_thrust(t, codes, phase_setting, feedback); thrust != expected {
+				t.Fatalf("phase setting %v gives ' %d ', hand wired ' %d '", phase_setting, thrust, expected)
+			}
+		}
+	}
+}
+
+// Reads its phase and an input and outputs their product
+const PRODUCT_AMPLIFIER string = "3,11,3,12,2,11,12,12,4,12,99,0,0"
+
+func TestFanTopologyCombiners(t *testing.T) {
+	codes, err := intcode.Parse(PRODUCT_AMPLIFIER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Branches output 2, 3 and 4, which are combined and multiplied by 5
+	var tests []struct {
+		combiner string
+		thrust   int
+	} = []struct {
+		combiner string
+		thrust   int
+	}{
+		{"sum", 45},
+		{"product", 120},
+		{"max", 20},
+		{"min", 10},
+	}
+
+	for _, test := range tests {
+		t.Run(test.combiner, func(t *testing.T) {
+			var topology Topology = fan_topology(3, test.combiner)
+			run, err := new_topology_run(topology, intcode.NewFromCodes(codes), []int{2, 3, 4, 5})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if thrust, err := run.thrust(1, topology.Output); err != nil || thrust != test.thrust {
+				t.Fatalf("thrust ' %d ' with ' %v ', expected ' %d '", thrust, err, test.thrust)
+			}
+		})
+	}
+}
+
+func TestLoadTopology(t *testing.T) {
+	codes, err := intcode.Parse(PRODUCT_AMPLIFIER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A diamond: A outputs 2, B and C 6 and 8, joined into 14 and D 70
+	var file_name string = filepath.Join(t.TempDir(), "diamond.json")
+	err = os.WriteFile(file_name, []byte(`{"stages": [
+		{"name": "A", "inputs": ["input"]},
+		{"name": "B", "inputs": ["A"]},
+		{"name": "C", "inputs": ["A"]},
+		{"name": "join", "combiner": "sum", "inputs": ["B", "C"]},
+		{"name": "D", "inputs": ["join"]}
+	], "output": "D"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	topology, err := load_topology(file_name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amplifiers := topology.Amplifiers(); !slices.Equal(amplifiers, []string{"A", "B", "C", "D"}) {
+		t.Fatalf("amplifiers %v, expected [A B C D]", amplifiers)
+	}
+	run, err := new_topology_run(topology, intcode.NewFromCodes(codes), []int{2, 3, 4, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thrust, err := run.thrust(1, topology.Output); err != nil || thrust != 70 {
+		t.Fatalf("thrust ' %d ' with ' %v ', expected ' 70 '", thrust, err)
+	}
+
+	// The search tries every phase on every amplifier
+	var controller AmplifierController = AmplifierController{topology, 2, 5, 1, codes}
+	maximum, err := controller.get_maximum_thrust(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maximum.thrust != 100 || len(maximum.phase_settings) != 4 {
+		t.Fatalf("maximum ' %d ' at %v, expected ' 100 ' at 4 settings", maximum.thrust, maximum.phase_settings)
+	}
+
+	err = os.WriteFile(file_name, []byte(`{"stages": [{"name": "A", "combiner": "mean", "inputs": ["input"]}], "output": "A"}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := load_topology(file_name); err == nil || !strings.Contains(err.Error(), "mean") {
+		t.Fatalf("unknown combiner loaded with ' %v '", err)
+	}
+}
+
+// Reads its phase, then twice reads a value and outputs it plus the phase
+const ADDING_AMPLIFIER string = "3,18,3,19,1,19,18,19,4,19,1001,20,-1,20,1005,20,2,99,0,0,2"
+
+func TestNestedLoopTopology(t *testing.T) {
+	if stages := nested_loop_topology(5).Stages; !slices.Equal(stages[2].Inputs, []string{"B", "D"}) || !slices.Equal(stages[0].Inputs, []string{TOPOLOGY_INPUT, "E"}) {
+		t.Fatalf("five amplifiers wired %+v", stages)
+	}
+	if stages := nested_loop_topology(2).Stages; !slices.Equal(stages[1].Inputs, []string{"A"}) {
+		t.Fatalf("two amplifiers wired %+v", stages)
+	}
+
+	codes, err := intcode.Parse(ADDING_AMPLIFIER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A turns 0 into 1 and B into 11, which C turns into 111 for both A and
+	// B. A then turns it into 112 and halts, and B, reading 111 before 112,
+	// outputs 121 and halts, which C turns into 221
+	var topology Topology = nested_loop_topology(3)
+	run, err := new_topology_run(topology, intcode.NewFromCodes(codes), []int{1, 10, 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thrust, err := run.thrust(0, topology.Output); err != nil || thrust != 221 {
+		t.Fatalf("thrust ' %d ' with ' %v ', expected ' 221 '", thrust, err)
+	}
+
+	// Sized to the phase range given to the search
+	topology, err = topology_from_argument("nested", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var controller AmplifierController = AmplifierController{topology, 1, 4, 0, codes}
+	maximum, err := controller.get_maximum_thrust(context.Background(), 2)
+	if err != nil || len(maximum.phase_settings) == 0 {
+		t.Fatalf("maximum ' %d ' at %v with ' %v '", maximum.thrust, maximum.phase_settings, err)
+	}
+}
@@ -7,228 +7,9 @@ import (
 	"image/color"
 	"image/png"
 	"os"
-	"strconv"
-	"strings"
-)
-
-// ----------------------- Opcode Struct Start -----------------------
-
-type Opcode struct {
-	opcode int
-	tags   []int
-}
-
-func get_opcode(value int) Opcode {
-	var opcode int = value % 100
-	var tags []int = make([]int, 0)
-	value = value / 100
-
-	for value != 0 {
-		var tag int = value % 10
-		// Only valid tags
-		if tag < 0 || tag > 2 {
-			fmt.Printf("Tag value not recognized\n")
-			os.Exit(1)
-		}
-
-		tags = append(tags, tag)
-		value = value / 10
-	}
-
-	// Only valid opcodes
-	if (opcode < 1 || opcode > 9) && opcode != 99 {
-		fmt.Printf("Opcode value not recognized\n")
-		os.Exit(1)
-	}
-
-	return Opcode{opcode, tags}
-}
 
-// ----------------------- Opcode Struct End -----------------------
-
-// ----------------------- IntCode Computer Struct Start -----------------------
-
-type IntCodeComputer struct {
-	state                string
-	input                []int
-	input_pointer        int
-	memory               []int
-	memory_pointer       int
-	memory_default_value int
-	relative_pointer     int
-	output               []int
-}
-
-func (computer *IntCodeComputer) extend_memory(position int) {
-	var missing_entries int = position - (len(computer.memory) - 1)
-	for i := 0; i < missing_entries; i++ {
-		computer.memory = append(computer.memory, computer.memory_default_value)
-	}
-}
-
-func (computer *IntCodeComputer) transform_to_arguments(opcode Opcode, number_arg int, writing_args int) []int {
-	var arguments []int = make([]int, 0, number_arg)
-	// Iterate over arguments
-	for arg_index := 0; arg_index < number_arg; arg_index++ {
-
-		var type_argument int
-		if arg_index >= len(opcode.tags) {
-			// By omission type is 0
-			type_argument = 0
-		} else {
-			// Else type is given by opcode tag
-			type_argument = opcode.tags[arg_index]
-		}
-
-		computer.extend_memory(computer.memory_pointer + 1 + arg_index)
-		var argument int = computer.memory[computer.memory_pointer+1+arg_index]
-
-		if arg_index >= number_arg-writing_args {
-			// Writing position
-			if type_argument == 2 {
-				// Relative mode writing
-				var argument_value int = computer.relative_pointer + argument
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 0 {
-				// Position mode writing
-				arguments = append(arguments, argument)
-			}
-		} else {
-			// Reading position
-			if type_argument == 2 {
-				// Relative mode
-				var position int = computer.relative_pointer + argument
-				computer.extend_memory(position)
-				var argument_value int = computer.memory[position]
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 1 {
-				// Immediate Mode
-				arguments = append(arguments, argument)
-			} else if type_argument == 0 {
-				// Position Mode
-				computer.extend_memory(argument)
-				var argument_value int = computer.memory[argument]
-				arguments = append(arguments, argument_value)
-			}
-		}
-	}
-
-	return arguments
-}
-
-func (computer *IntCodeComputer) run() {
-	computer.state = "running"
-	for computer.state == "running" {
-
-		computer.extend_memory(computer.memory_pointer)
-		var current_opcode Opcode = get_opcode(computer.memory[computer.memory_pointer])
-		switch current_opcode.opcode {
-		// Halting
-		case 99:
-			computer.state = "halted"
-
-		// Addition
-		case 1:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] + arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Multiplication
-		case 2:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] * arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Input
-		case 3:
-			if computer.input_pointer >= len(computer.input) {
-				// Computer must wait for more input
-				computer.state = "paused"
-			} else {
-				var arguments []int = computer.transform_to_arguments(current_opcode, 1, 1)
-				computer.extend_memory((arguments[0]))
-				computer.memory[arguments[0]] = computer.input[computer.input_pointer]
-
-				//Advance pointers
-				computer.memory_pointer = computer.memory_pointer + 2
-				computer.input_pointer = computer.input_pointer + 1
-			}
-
-		// Output
-		case 4:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.output = append(computer.output, arguments[0])
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-
-		// Jump if True
-		case 5:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] != 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Jump if False
-		case 6:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] == 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Less than
-		case 7:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] < arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Equals
-		case 8:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] == arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Adjust relative base
-		case 9:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.relative_pointer = computer.relative_pointer + arguments[0]
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-
-		// Should not happen
-		default:
-			fmt.Println("Code not recognized")
-			os.Exit(1)
-		}
-	}
-}
-
-// ----------------------- IntCode Computer Struct End -----------------------
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
 
 // ----------------------- Robot Struct End -----------------------
 
@@ -270,12 +51,12 @@ type Robot struct {
 	bottom_left Position
 	top_right   Position
 	panel       map[Position]int
-	computer    IntCodeComputer
+	computer    intcode.IntCodeComputer
 }
 
 func (robot *Robot) run() {
-	var current_state string = robot.computer.state
-	for current_state != "halted" {
+	var current_state intcode.State = robot.computer.State()
+	for current_state != intcode.Halted {
 
 		// Read current tile
 		current_tile, tile_exists := robot.panel[robot.position]
@@ -285,13 +66,17 @@ func (robot *Robot) run() {
 		}
 
 		// Update and run robot
-		robot.computer.input = append(robot.computer.input, current_tile)
-		robot.computer.run()
+		robot.computer.AddInput(current_tile)
+		err := robot.computer.Run()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 
 		// Retrieve values
-		current_state = robot.computer.state
-		len_output := len(robot.computer.output)
-		paint, turn_value := robot.computer.output[len_output-2], robot.computer.output[len_output-1]
+		current_state = robot.computer.State()
+		output := robot.computer.Output()
+		paint, turn_value := output[len(output)-2], output[len(output)-1]
 
 		// Robot actuates
 		robot.panel[robot.position] = paint
@@ -405,18 +190,10 @@ func main() {
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 
-		// Iterate over split
-		var split []string = strings.Split(scanner.Text(), ",")
-		var values_converted []int = make([]int, 0)
-		for _, code := range split {
-			code_converted, _ := strconv.Atoi(code)
-			values_converted = append(values_converted, code_converted)
-		}
+		var line string = scanner.Text()
 
 		// Part 1
-		var inputs []int = make([]int, 0)
-		var outputs []int = make([]int, 0)
-		var robot_computer IntCodeComputer = IntCodeComputer{"booting", inputs, 0, values_converted, 0, 0, 0, outputs}
+		robot_computer, _ := intcode.New(line)
 		var robot Robot = Robot{Position{0, 0}, "Up", Position{0, 0}, Position{0, 0}, make(map[Position]int), robot_computer}
 
 		robot.run()
@@ -425,9 +202,7 @@ func main() {
 		fmt.Printf("The robot painted at least ' %d ' cells (part 1)\n", least_painted_cells)
 
 		// Part 2
-		var fixed_inputs []int = make([]int, 0)
-		var fixed_outputs []int = make([]int, 0)
-		var fixed_robot_computer IntCodeComputer = IntCodeComputer{"booting", fixed_inputs, 0, values_converted, 0, 0, 0, fixed_outputs}
+		fixed_robot_computer, _ := intcode.New(line)
 		var fixed_panel map[Position]int = make(map[Position]int)
 		fixed_panel[Position{0, 0}] = 1
 		var fixed_robot Robot = Robot{Position{0, 0}, "Up", Position{0, 0}, Position{0, 0}, fixed_panel, fixed_robot_computer}
@@ -4,228 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
-	"strings"
-)
-
-// ----------------------- Opcode Struct Start -----------------------
-
-type Opcode struct {
-	opcode int
-	tags   []int
-}
-
-func get_opcode(value int) Opcode {
-	var opcode int = value % 100
-	var tags []int = make([]int, 0)
-	value = value / 100
-
-	for value != 0 {
-		var tag int = value % 10
-		// Only valid tags
-		if tag < 0 || tag > 2 {
-			fmt.Printf("Tag value not recognized: ' %d '\n", tag)
-			os.Exit(1)
-		}
-
-		tags = append(tags, tag)
-		value = value / 10
-	}
-
-	// Only valid opcodes
-	if (opcode < 1 || opcode > 9) && opcode != 99 {
-		fmt.Printf("Opcode value not recognized: ' %d '\n", opcode)
-		os.Exit(1)
-	}
-
-	return Opcode{opcode, tags}
-}
-
-// ----------------------- Opcode Struct End -----------------------
-
-// ----------------------- IntCode Computer Struct Start -----------------------
-
-type IntCodeComputer struct {
-	state                string
-	input                []int
-	input_pointer        int
-	memory               []int
-	memory_pointer       int
-	memory_default_value int
-	relative_pointer     int
-	output               []int
-}
-
-func (computer *IntCodeComputer) extend_memory(position int) {
-	var missing_entries int = position - (len(computer.memory) - 1)
-	for i := 0; i < missing_entries; i++ {
-		computer.memory = append(computer.memory, computer.memory_default_value)
-	}
-}
-
-func (computer *IntCodeComputer) transform_to_arguments(opcode Opcode, number_arg int, writing_args int) []int {
-	var arguments []int = make([]int, 0, number_arg)
-	// Iterate over arguments
-	for arg_index := 0; arg_index < number_arg; arg_index++ {
-
-		var type_argument int
-		if arg_index >= len(opcode.tags) {
-			// By omission type is 0
-			type_argument = 0
-		} else {
-			// Else type is given by opcode tag
-			type_argument = opcode.tags[arg_index]
-		}
-
-		computer.extend_memory(computer.memory_pointer + 1 + arg_index)
-		var argument int = computer.memory[computer.memory_pointer+1+arg_index]
-
-		if arg_index >= number_arg-writing_args {
-			// Writing position
-			if type_argument == 2 {
-				// Relative mode writing
-				var argument_value int = computer.relative_pointer + argument
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 0 {
-				// Position mode writing
-				arguments = append(arguments, argument)
-			}
-		} else {
-			// Reading position
-			if type_argument == 2 {
-				// Relative mode
-				var position int = computer.relative_pointer + argument
-				computer.extend_memory(position)
-				var argument_value int = computer.memory[position]
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 1 {
-				// Immediate Mode
-				arguments = append(arguments, argument)
-			} else if type_argument == 0 {
-				// Position Mode
-				computer.extend_memory(argument)
-				var argument_value int = computer.memory[argument]
-				arguments = append(arguments, argument_value)
-			}
-		}
-	}
-
-	return arguments
-}
-
-func (computer *IntCodeComputer) run() {
-	computer.state = "running"
-	for computer.state == "running" {
-
-		computer.extend_memory(computer.memory_pointer)
-		var current_opcode Opcode = get_opcode(computer.memory[computer.memory_pointer])
-		switch current_opcode.opcode {
-		// Halting
-		case 99:
-			computer.state = "halted"
-
-		// Addition
-		case 1:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] + arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Multiplication
-		case 2:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] * arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Input
-		case 3:
-			if computer.input_pointer >= len(computer.input) {
-				// Computer must wait for more input
-				computer.state = "paused"
-			} else {
-				var arguments []int = computer.transform_to_arguments(current_opcode, 1, 1)
-				computer.extend_memory((arguments[0]))
-				computer.memory[arguments[0]] = computer.input[computer.input_pointer]
-
-				//Advance pointers
-				computer.memory_pointer = computer.memory_pointer + 2
-				computer.input_pointer = computer.input_pointer + 1
-			}
 
-		// Output
-		case 4:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.output = append(computer.output, arguments[0])
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-
-		// Jump if True
-		case 5:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] != 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Jump if False
-		case 6:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] == 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Less than
-		case 7:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] < arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Equals
-		case 8:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] == arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Adjust relative base
-		case 9:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.relative_pointer = computer.relative_pointer + arguments[0]
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-
-		// Should not happen
-		default:
-			fmt.Println("Code not recognized")
-			os.Exit(1)
-		}
-	}
-}
-
-// ----------------------- IntCode Computer Struct End -----------------------
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
 
 // ----------------------- Game Struct End -----------------------
 
@@ -252,47 +33,58 @@ type Game struct {
 	top_left     Position
 	bottom_right Position
 	space        map[Position]int
-	computer     IntCodeComputer
+	computer     intcode.IntCodeComputer
 	paddle_x     int
 	ball_x       int
 }
 
 func (game *Game) run_computer() {
-	game.computer.run()
-	output := game.computer.output
-
-	for index := 0; index < len(output); index = index + 3 {
-		var x_position int = output[index]
-		var y_position int = output[index+1]
-		var code int = output[index+2]
-
-		if x_position == -1 && y_position == 0 {
-			// Scoring
-			game.score = code
-			continue
+	// Draw tile by tile until the game needs the joystick or is over
+	for {
+		err := game.computer.RunUntilOutput(3)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
 
-		game.space[Position{x_position, y_position}] = code
-
-		// Update X Limits
-		if x_position < game.top_left.x {
-			game.top_left.x = x_position
-		} else if x_position > game.bottom_right.x {
-			game.bottom_right.x = x_position
-		}
-		// Update Y Limits
-		if y_position < game.top_left.y {
-			game.top_left.y = y_position
-		} else if y_position > game.bottom_right.y {
-			game.bottom_right.y = y_position
+		if game.computer.State() != intcode.Paused {
+			break
 		}
+		game.draw_tile(game.computer.ConsumeOutput(3))
+	}
+}
 
-		// Check if ball or paddle
-		if ObjectCodes[code].name == "Ball" {
-			game.ball_x = x_position
-		} else if ObjectCodes[code].name == "HorizontalPaddle" {
-			game.paddle_x = x_position
-		}
+func (game *Game) draw_tile(tile []int) {
+	var x_position int = tile[0]
+	var y_position int = tile[1]
+	var code int = tile[2]
+
+	if x_position == -1 && y_position == 0 {
+		// Scoring
+		game.score = code
+		return
+	}
+
+	game.space[Position{x_position, y_position}] = code
+
+	// Update X Limits
+	if x_position < game.top_left.x {
+		game.top_left.x = x_position
+	} else if x_position > game.bottom_right.x {
+		game.bottom_right.x = x_position
+	}
+	// Update Y Limits
+	if y_position < game.top_left.y {
+		game.top_left.y = y_position
+	} else if y_position > game.bottom_right.y {
+		game.bottom_right.y = y_position
+	}
+
+	// Check if ball or paddle
+	if ObjectCodes[code].name == "Ball" {
+		game.ball_x = x_position
+	} else if ObjectCodes[code].name == "HorizontalPaddle" {
+		game.paddle_x = x_position
 	}
 }
 
@@ -301,8 +93,7 @@ func (game *Game) run_game() int {
 	var game_finished bool = false
 
 	for !game_finished {
-		game.computer.input = append(game.computer.input, current_input)
-		game.space = make(map[Position]int)
+		game.computer.AddInput(current_input)
 		game.run_computer()
 
 		if game.ball_x == game.paddle_x {
@@ -368,17 +159,9 @@ func main() {
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 
-		// Iterate over split
-		var split []string = strings.Split(scanner.Text(), ",")
-		var values_converted []int = make([]int, 0)
-		for _, code := range split {
-			code_converted, _ := strconv.Atoi(code)
-			values_converted = append(values_converted, code_converted)
-		}
+		var line string = scanner.Text()
 
-		var inputs []int = make([]int, 0)
-		var output []int = make([]int, 0)
-		var computer IntCodeComputer = IntCodeComputer{"booting", inputs, 0, values_converted, 0, 0, 0, output}
+		computer, _ := intcode.New(line)
 		var space map[Position]int = make(map[Position]int)
 		var game Game = Game{0, Position{0, 0}, Position{0, 0}, space, computer, 0, 0}
 		game.run_computer()
@@ -390,10 +173,8 @@ func main() {
 		fmt.Printf("Number of ' %s ': ' %d ' (part 1)\n", object, count)
 		fmt.Println("-------------------------------------------------")
 
-		inputs = make([]int, 0)
-		output = make([]int, 0)
-		values_converted[0] = 2
-		computer = IntCodeComputer{"booting", inputs, 0, values_converted, 0, 0, 0, output}
+		computer, _ = intcode.New(line)
+		computer.WriteMemory(0, 2)
 		space = make(map[Position]int)
 		game = Game{0, Position{0, 0}, Position{0, 0}, space, computer, 0, 0}
 		game.run_computer()
@@ -4,245 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
-	"strings"
-)
-
-// ----------------------- Opcode Struct Start -----------------------
-
-type Opcode struct {
-	opcode int
-	tags   []int
-}
-
-func get_opcode(value int) Opcode {
-	var opcode int = value % 100
-	var tags []int = make([]int, 0)
-	value = value / 100
-
-	for value != 0 {
-		var tag int = value % 10
-		// Only valid tags
-		if tag < 0 || tag > 2 {
-			fmt.Printf("Tag value not recognized: ' %d '\n", tag)
-			os.Exit(1)
-		}
-
-		tags = append(tags, tag)
-		value = value / 10
-	}
-
-	// Only valid opcodes
-	if (opcode < 1 || opcode > 9) && opcode != 99 {
-		fmt.Printf("Opcode value not recognized: ' %d '\n", opcode)
-		os.Exit(1)
-	}
-
-	return Opcode{opcode, tags}
-}
-
-// ----------------------- Opcode Struct End -----------------------
-
-// ----------------------- IntCode Computer Struct Start -----------------------
-
-type IntCodeComputer struct {
-	state                string
-	input                []int
-	input_pointer        int
-	memory               []int
-	memory_pointer       int
-	memory_default_value int
-	relative_pointer     int
-	output               []int
-}
-
-func (computer *IntCodeComputer) extend_memory(position int) {
-	var missing_entries int = position - (len(computer.memory) - 1)
-	for i := 0; i < missing_entries; i++ {
-		computer.memory = append(computer.memory, computer.memory_default_value)
-	}
-}
-
-func (computer *IntCodeComputer) transform_to_arguments(opcode Opcode, number_arg int, writing_args int) []int {
-	var arguments []int = make([]int, 0, number_arg)
-	// Iterate over arguments
-	for arg_index := 0; arg_index < number_arg; arg_index++ {
-
-		var type_argument int
-		if arg_index >= len(opcode.tags) {
-			// By omission type is 0
-			type_argument = 0
-		} else {
-			// Else type is given by opcode tag
-			type_argument = opcode.tags[arg_index]
-		}
-
-		computer.extend_memory(computer.memory_pointer + 1 + arg_index)
-		var argument int = computer.memory[computer.memory_pointer+1+arg_index]
-
-		if arg_index >= number_arg-writing_args {
-			// Writing position
-			if type_argument == 2 {
-				// Relative mode writing
-				var argument_value int = computer.relative_pointer + argument
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 0 {
-				// Position mode writing
-				arguments = append(arguments, argument)
-			}
-		} else {
-			// Reading position
-			if type_argument == 2 {
-				// Relative mode
-				var position int = computer.relative_pointer + argument
-				computer.extend_memory(position)
-				var argument_value int = computer.memory[position]
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 1 {
-				// Immediate Mode
-				arguments = append(arguments, argument)
-			} else if type_argument == 0 {
-				// Position Mode
-				computer.extend_memory(argument)
-				var argument_value int = computer.memory[argument]
-				arguments = append(arguments, argument_value)
-			}
-		}
-	}
-
-	return arguments
-}
-
-func (computer *IntCodeComputer) run() {
-	computer.state = "running"
-	for computer.state == "running" {
-
-		computer.extend_memory(computer.memory_pointer)
-		var current_opcode Opcode = get_opcode(computer.memory[computer.memory_pointer])
-		switch current_opcode.opcode {
-		// Halting
-		case 99:
-			computer.state = "halted"
-
-		// Addition
-		case 1:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] + arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Multiplication
-		case 2:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] * arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Input
-		case 3:
-			if computer.input_pointer >= len(computer.input) {
-				// Computer must wait for more input
-				computer.state = "paused"
-			} else {
-				var arguments []int = computer.transform_to_arguments(current_opcode, 1, 1)
-				computer.extend_memory((arguments[0]))
-				computer.memory[arguments[0]] = computer.input[computer.input_pointer]
-
-				//Advance pointers
-				computer.memory_pointer = computer.memory_pointer + 2
-				computer.input_pointer = computer.input_pointer + 1
-			}
-
-		// Output
-		case 4:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.output = append(computer.output, arguments[0])
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-			computer.state = "paused"
-
-		// Jump if True
-		case 5:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] != 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Jump if False
-		case 6:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] == 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Less than
-		case 7:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] < arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Equals
-		case 8:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] == arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
 
-		// Adjust relative base
-		case 9:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.relative_pointer = computer.relative_pointer + arguments[0]
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-
-		// Should not happen
-		default:
-			fmt.Println("Code not recognized")
-			os.Exit(1)
-		}
-	}
-}
-
-func make_deep_copy(computer IntCodeComputer) IntCodeComputer {
-	copy_computer := computer
-
-	copy_computer.input = make([]int, len(computer.input))
-	copy_computer.memory = make([]int, len(computer.memory))
-	copy_computer.output = make([]int, len(computer.output))
-
-	copy(copy_computer.input, computer.input)
-	copy(copy_computer.memory, computer.memory)
-	copy(copy_computer.output, computer.output)
-
-	//fmt.Printf("%p\n", computer.memory)
-	//fmt.Printf("%p\n", copy_computer.memory)
-	return copy_computer
-}
-
-// ----------------------- IntCode Computer Struct End -----------------------
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
 
 // ----------------------- Droid Struct Start -----------------------
 
@@ -287,7 +51,7 @@ var ObjectCodes map[string]string = map[string]string{
 
 type SubDroid struct {
 	position Position
-	computer IntCodeComputer
+	computer intcode.IntCodeComputer
 }
 
 type Droid struct {
@@ -295,11 +59,11 @@ type Droid struct {
 	top_right      Position
 	bottom_left    Position
 	mapping        map[Position]MapPoint
-	saved_computer IntCodeComputer
+	saved_computer intcode.IntCodeComputer
 }
 
 func (droid *Droid) run_droid_until_oxygen() (Position, int) {
-	var initial_subdroid SubDroid = SubDroid{droid.saved_position, make_deep_copy(droid.saved_computer)}
+	var initial_subdroid SubDroid = SubDroid{droid.saved_position, intcode.MakeDeepCopy(droid.saved_computer)}
 	var subdroids []SubDroid = make([]SubDroid, 0)
 	subdroids = append(subdroids, initial_subdroid)
 
@@ -322,11 +86,16 @@ func (droid *Droid) run_droid_until_oxygen() (Position, int) {
 				}
 
 				// Valid direction to explore and move robot
-				var new_subdroid SubDroid = SubDroid{subdroid.position, make_deep_copy(subdroid.computer)}
-				new_subdroid.computer.input = append(new_subdroid.computer.input, direction_code)
-				new_subdroid.computer.run()
-				var status_code int = new_subdroid.computer.output[len(new_subdroid.computer.output)-1]
-				new_subdroid.computer.output = make([]int, 0)
+				var new_subdroid SubDroid = SubDroid{subdroid.position, intcode.MakeDeepCopy(subdroid.computer)}
+				new_subdroid.computer.AddInput(direction_code)
+				err := new_subdroid.computer.Run()
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				var output []int = new_subdroid.computer.Output()
+				var status_code int = output[len(output)-1]
+				new_subdroid.computer.ClearOutput()
 
 				switch status_code {
 				case 0:
@@ -451,16 +220,8 @@ func main() {
 	for scanner.Scan() {
 
 		var line string = scanner.Text()
-		var split []string = strings.Split(line, ",")
-		var values_converted []int = make([]int, 0)
-		for _, code := range split {
-			code_converted, _ := strconv.Atoi(code)
-			values_converted = append(values_converted, code_converted)
-		}
 
-		inputs := make([]int, 0)
-		outputs := make([]int, 0)
-		var computer IntCodeComputer = IntCodeComputer{"booting", inputs, 0, values_converted, 0, 0, 0, outputs}
+		computer, _ := intcode.New(line)
 
 		position_0 := Position{0, 0}
 		mapping := make(map[Position]MapPoint)
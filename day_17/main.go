@@ -6,240 +6,9 @@ import (
 	"os"
 	"strconv"
 	"strings"
-)
-
-// ----------------------- Opcode Struct Start -----------------------
-
-type Opcode struct {
-	opcode int
-	tags   []int
-}
-
-func get_opcode(value int) Opcode {
-	var opcode int = value % 100
-	var tags []int = make([]int, 0)
-	value = value / 100
-
-	for value != 0 {
-		var tag int = value % 10
-		// Only valid tags
-		if tag < 0 || tag > 2 {
-			fmt.Printf("Tag value not recognized: ' %d '\n", tag)
-			os.Exit(1)
-		}
-
-		tags = append(tags, tag)
-		value = value / 10
-	}
-
-	// Only valid opcodes
-	if (opcode < 1 || opcode > 9) && opcode != 99 {
-		fmt.Printf("Opcode value not recognized: ' %d '\n", opcode)
-		os.Exit(1)
-	}
-
-	return Opcode{opcode, tags}
-}
-
-// ----------------------- Opcode Struct End -----------------------
-
-// ----------------------- IntCode Computer Struct Start -----------------------
-
-type IntCodeComputer struct {
-	state                string
-	input                []int
-	input_pointer        int
-	memory               []int
-	memory_pointer       int
-	memory_default_value int
-	relative_pointer     int
-	output               []int
-}
-
-func (computer *IntCodeComputer) extend_memory(position int) {
-	var missing_entries int = position - (len(computer.memory) - 1)
-	for i := 0; i < missing_entries; i++ {
-		computer.memory = append(computer.memory, computer.memory_default_value)
-	}
-}
-
-func (computer *IntCodeComputer) transform_to_arguments(opcode Opcode, number_arg int, writing_args int) []int {
-	var arguments []int = make([]int, 0, number_arg)
-	// Iterate over arguments
-	for arg_index := 0; arg_index < number_arg; arg_index++ {
-
-		var type_argument int
-		if arg_index >= len(opcode.tags) {
-			// By omission type is 0
-			type_argument = 0
-		} else {
-			// Else type is given by opcode tag
-			type_argument = opcode.tags[arg_index]
-		}
-
-		computer.extend_memory(computer.memory_pointer + 1 + arg_index)
-		var argument int = computer.memory[computer.memory_pointer+1+arg_index]
-
-		if arg_index >= number_arg-writing_args {
-			// Writing position
-			if type_argument == 2 {
-				// Relative mode writing
-				var argument_value int = computer.relative_pointer + argument
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 0 {
-				// Position mode writing
-				arguments = append(arguments, argument)
-			}
-		} else {
-			// Reading position
-			if type_argument == 2 {
-				// Relative mode
-				var position int = computer.relative_pointer + argument
-				computer.extend_memory(position)
-				var argument_value int = computer.memory[position]
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 1 {
-				// Immediate Mode
-				arguments = append(arguments, argument)
-			} else if type_argument == 0 {
-				// Position Mode
-				computer.extend_memory(argument)
-				var argument_value int = computer.memory[argument]
-				arguments = append(arguments, argument_value)
-			}
-		}
-	}
-
-	return arguments
-}
-
-func (computer *IntCodeComputer) run() {
-	computer.state = "running"
-	for computer.state == "running" {
-
-		computer.extend_memory(computer.memory_pointer)
-		var current_opcode Opcode = get_opcode(computer.memory[computer.memory_pointer])
-		switch current_opcode.opcode {
-		// Halting
-		case 99:
-			computer.state = "halted"
-
-		// Addition
-		case 1:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] + arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Multiplication
-		case 2:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] * arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Input
-		case 3:
-			if computer.input_pointer >= len(computer.input) {
-				// Computer must wait for more input
-				computer.state = "paused"
-			} else {
-				var arguments []int = computer.transform_to_arguments(current_opcode, 1, 1)
-				computer.extend_memory((arguments[0]))
-				computer.memory[arguments[0]] = computer.input[computer.input_pointer]
 
-				//Advance pointers
-				computer.memory_pointer = computer.memory_pointer + 2
-				computer.input_pointer = computer.input_pointer + 1
-			}
-
-		// Output
-		case 4:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.output = append(computer.output, arguments[0])
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-
-		// Jump if True
-		case 5:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] != 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Jump if False
-		case 6:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] == 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Less than
-		case 7:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] < arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Equals
-		case 8:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] == arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Adjust relative base
-		case 9:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.relative_pointer = computer.relative_pointer + arguments[0]
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-
-		// Should not happen
-		default:
-			fmt.Println("Code not recognized")
-			os.Exit(1)
-		}
-	}
-}
-
-func make_deep_copy(computer IntCodeComputer) IntCodeComputer {
-	copy_computer := computer
-
-	copy_computer.input = make([]int, len(computer.input))
-	copy_computer.memory = make([]int, len(computer.memory))
-	copy_computer.output = make([]int, len(computer.output))
-
-	copy(copy_computer.input, computer.input)
-	copy(copy_computer.memory, computer.memory)
-	copy(copy_computer.output, computer.output)
-
-	return copy_computer
-}
-
-// ----------------------- IntCode Computer Struct End -----------------------
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
 
 // ----------------------- InterfaceASCII Struct Start -----------------------
 
@@ -296,54 +65,43 @@ type InterfaceASCII struct {
 	droid_position Position
 	droid_type     string
 	mapping        map[Position]Object
-	computer       IntCodeComputer
+	host           *intcode.ASCIIComputer
 }
 
 func (ascii *InterfaceASCII) build_map() {
-	ascii.computer.run()
-	output := ascii.computer.output
-
-	index_cutoff, last_new_line := -1, false
-	var x_position, y_position int = 0, 0
-	for index, code := range output {
-
-		// Update limits
-		if x_position > ascii.bottom_right.x {
-			ascii.bottom_right.x = x_position
+	for y_position := 0; ; y_position++ {
+		line, err := ascii.host.ReadLine()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
-		if y_position > ascii.bottom_right.y {
-			ascii.bottom_right.y = y_position
+		// Map ends on an empty line
+		if line == "" {
+			break
 		}
 
-		code_converted := ConvertCode[code]
-		// Converting code to symbol
-		if code == 10 {
-			// New line
-			if last_new_line {
-				index_cutoff = index
-				break
+		for x_position, character := range line {
+			// Update limits
+			if x_position > ascii.bottom_right.x {
+				ascii.bottom_right.x = x_position
+			}
+			if y_position > ascii.bottom_right.y {
+				ascii.bottom_right.y = y_position
 			}
 
-			x_position = 0
-			y_position = y_position + 1
-			last_new_line = true
-		} else if code_converted == "droid_up" || code_converted == "droid_down" || code_converted == "droid_left" || code_converted == "droid_right" {
-			// Droid
-			last_new_line = false
-			ascii.droid_type = code_converted
-			ascii.droid_position = Position{x_position, y_position}
-			ascii.mapping[Position{x_position, y_position}] = "scaffold"
-			x_position = x_position + 1
+			code_converted := ConvertCode[int(character)]
+			if code_converted == "droid_up" || code_converted == "droid_down" || code_converted == "droid_left" || code_converted == "droid_right" {
+				// Droid
+				ascii.droid_type = code_converted
+				ascii.droid_position = Position{x_position, y_position}
+				ascii.mapping[Position{x_position, y_position}] = "scaffold"
 
-		} else {
-			// Else place object
-			last_new_line = false
-			ascii.mapping[Position{x_position, y_position}] = code_converted
-			x_position = x_position + 1
+			} else {
+				// Else place object
+				ascii.mapping[Position{x_position, y_position}] = code_converted
+			}
 		}
 	}
-
-	ascii.computer.output = ascii.computer.output[index_cutoff+1:]
 }
 
 func (ascii *InterfaceASCII) compute_intersections() int {
@@ -479,38 +237,32 @@ func (ascii *InterfaceASCII) count_unvisited_cells() int {
 }
 
 func (ascii *InterfaceASCII) start_moving(codification []string, patterns [][]string) int {
-	var NEW_LINE int = 10
-	var COMMA int = int(',')
-	var VIDEO_FEED int = int('n')
+	var COMMA string = ","
+	var VIDEO_FEED string = "n"
 
-	var input [][]int = make([][]int, 0)
-
-	// Transform codification
-	codification_transformed := transform_codification_to_input(codification, COMMA, NEW_LINE)
-	input = append(input, codification_transformed)
-
-	// Add patterns to input
+	// Main routine, then its functions
+	var input []string = make([]string, 0)
+	input = append(input, strings.Join(codification, COMMA))
 	for _, pattern := range patterns {
-		pattern_transformed := transform_codification_to_input(pattern, COMMA, NEW_LINE)
-		input = append(input, pattern_transformed)
+		input = append(input, strings.Join(pattern, COMMA))
 	}
-
 	// No video feed back
-	video_feed := make([]int, 0)
-	video_feed = append(video_feed, VIDEO_FEED)
-	video_feed = append(video_feed, NEW_LINE)
-	input = append(input, video_feed)
+	input = append(input, VIDEO_FEED)
 
 	// Start computer
 	for _, input_line := range input {
-		print_output_string(ascii.computer.output)
-		ascii.computer.output = make([]int, 0)
-		fmt.Printf("%+v\n", input_line)
-		ascii.computer.input = append(ascii.computer.input, input_line...)
-		ascii.computer.run()
+		fmt.Printf("- %s", ascii.host.Text())
+		fmt.Printf("%+v\n", intcode.EncodeASCII(input_line+"\n"))
+		ascii.host.WriteLine(input_line)
+		err := ascii.host.Run()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 	}
 
-	return ascii.computer.output[len(ascii.computer.output)-1]
+	var results []int = ascii.host.Results()
+	return results[len(results)-1]
 }
 
 // ----------------------- InterfaceASCII Struct End -----------------------
@@ -647,29 +399,6 @@ func check_code_in_codes(code string, codes []string) bool {
 	return false
 }
 
-func transform_codification_to_input(codification []string, sep int, end int) []int {
-	transformed := make([]int, 0)
-
-	for _, code := range codification {
-		for _, letter_code := range code {
-			transformed = append(transformed, int(letter_code))
-		}
-		transformed = append(transformed, sep)
-	}
-	transformed[len(transformed)-1] = end
-
-	return transformed
-}
-
-func print_output_string(output []int) {
-	var final string = ""
-	for _, code := range output {
-		final = final + string(rune(code))
-	}
-
-	fmt.Printf("- %s", final)
-}
-
 func main() {
 
 	// ----------------- SETUP INPUT TXT -----------------
@@ -684,19 +413,11 @@ func main() {
 	for scanner.Scan() {
 
 		var line string = scanner.Text()
-		var split []string = strings.Split(line, ",")
-		var values_converted []int = make([]int, 0)
-		for _, code := range split {
-			code_converted, _ := strconv.Atoi(code)
-			values_converted = append(values_converted, code_converted)
-		}
 
-		inputs := make([]int, 0)
-		outputs := make([]int, 0)
-		var computer IntCodeComputer = IntCodeComputer{"booting", inputs, 0, values_converted, 0, 0, 0, outputs}
+		computer, _ := intcode.New(line)
 
 		position_0 := Position{0, 0}
-		var ascii InterfaceASCII = InterfaceASCII{position_0, position_0, position_0, "unknown", make(map[Position]string), computer}
+		var ascii InterfaceASCII = InterfaceASCII{position_0, position_0, position_0, "unknown", make(map[Position]string), intcode.NewASCII(&computer)}
 
 		// Part 1
 		ascii.build_map()
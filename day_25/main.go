@@ -4,242 +4,10 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
-)
-
-// ----------------------- Opcode Struct Start -----------------------
-
-type Opcode struct {
-	opcode int
-	tags   []int
-}
-
-func get_opcode(value int) Opcode {
-	var opcode int = value % 100
-	var tags []int = make([]int, 0)
-	value = value / 100
-
-	for value != 0 {
-		var tag int = value % 10
-		// Only valid tags
-		if tag < 0 || tag > 2 {
-			fmt.Printf("Tag value not recognized: ' %d '\n", tag)
-			os.Exit(1)
-		}
-
-		tags = append(tags, tag)
-		value = value / 10
-	}
-
-	// Only valid opcodes
-	if (opcode < 1 || opcode > 9) && opcode != 99 {
-		fmt.Printf("Opcode value not recognized: ' %d '\n", opcode)
-		os.Exit(1)
-	}
-
-	return Opcode{opcode, tags}
-}
-
-// ----------------------- Opcode Struct End -----------------------
-
-// ----------------------- IntCode Computer Struct Start -----------------------
-
-type IntCodeComputer struct {
-	state                string
-	input                []int
-	input_pointer        int
-	memory               []int
-	memory_pointer       int
-	memory_default_value int
-	relative_pointer     int
-	output               []int
-}
-
-func (computer *IntCodeComputer) extend_memory(position int) {
-	var missing_entries int = position - (len(computer.memory) - 1)
-	for i := 0; i < missing_entries; i++ {
-		computer.memory = append(computer.memory, computer.memory_default_value)
-	}
-}
-
-func (computer *IntCodeComputer) transform_to_arguments(opcode Opcode, number_arg int, writing_args int) []int {
-	var arguments []int = make([]int, 0, number_arg)
-	// Iterate over arguments
-	for arg_index := 0; arg_index < number_arg; arg_index++ {
-
-		var type_argument int
-		if arg_index >= len(opcode.tags) {
-			// By omission type is 0
-			type_argument = 0
-		} else {
-			// Else type is given by opcode tag
-			type_argument = opcode.tags[arg_index]
-		}
-
-		computer.extend_memory(computer.memory_pointer + 1 + arg_index)
-		var argument int = computer.memory[computer.memory_pointer+1+arg_index]
-
-		if arg_index >= number_arg-writing_args {
-			// Writing position
-			if type_argument == 2 {
-				// Relative mode writing
-				var argument_value int = computer.relative_pointer + argument
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 0 {
-				// Position mode writing
-				arguments = append(arguments, argument)
-			}
-		} else {
-			// Reading position
-			if type_argument == 2 {
-				// Relative mode
-				var position int = computer.relative_pointer + argument
-				computer.extend_memory(position)
-				var argument_value int = computer.memory[position]
-				arguments = append(arguments, argument_value)
-			} else if type_argument == 1 {
-				// Immediate Mode
-				arguments = append(arguments, argument)
-			} else if type_argument == 0 {
-				// Position Mode
-				computer.extend_memory(argument)
-				var argument_value int = computer.memory[argument]
-				arguments = append(arguments, argument_value)
-			}
-		}
-	}
-
-	return arguments
-}
-
-func (computer *IntCodeComputer) run() {
-	computer.state = "running"
-	for computer.state == "running" {
-
-		computer.extend_memory(computer.memory_pointer)
-		var current_opcode Opcode = get_opcode(computer.memory[computer.memory_pointer])
-		switch current_opcode.opcode {
-		// Halting
-		case 99:
-			computer.state = "halted"
-
-		// Addition
-		case 1:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] + arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Multiplication
-		case 2:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			computer.memory[arguments[2]] = arguments[0] * arguments[1]
-
-			//Advance pointer
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Input
-		case 3:
-			if computer.input_pointer >= len(computer.input) {
-				// Computer must wait for more input
-				computer.state = "paused"
-			} else {
-				var arguments []int = computer.transform_to_arguments(current_opcode, 1, 1)
-				computer.extend_memory((arguments[0]))
-				computer.memory[arguments[0]] = computer.input[computer.input_pointer]
-
-				//Advance pointers
-				computer.memory_pointer = computer.memory_pointer + 2
-				computer.input_pointer = computer.input_pointer + 1
-			}
-
-		// Output
-		case 4:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.output = append(computer.output, arguments[0])
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
 
-		// Jump if True
-		case 5:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] != 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Jump if False
-		case 6:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 2, 0)
-			if arguments[0] == 0 {
-				computer.memory_pointer = arguments[1]
-			} else {
-				computer.memory_pointer = computer.memory_pointer + 3
-			}
-
-		// Less than
-		case 7:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] < arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Equals
-		case 8:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 3, 1)
-			computer.extend_memory(arguments[2])
-			if arguments[0] == arguments[1] {
-				computer.memory[arguments[2]] = 1
-			} else {
-				computer.memory[arguments[2]] = 0
-			}
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 4
-
-		// Adjust relative base
-		case 9:
-			var arguments []int = computer.transform_to_arguments(current_opcode, 1, 0)
-			computer.relative_pointer = computer.relative_pointer + arguments[0]
-
-			//Advance pointers
-			computer.memory_pointer = computer.memory_pointer + 2
-
-		// Should not happen
-		default:
-			fmt.Println("Code not recognized")
-			os.Exit(1)
-		}
-	}
-}
-
-func make_deep_copy(computer IntCodeComputer) IntCodeComputer {
-	copy_computer := computer
-
-	copy_computer.input = make([]int, len(computer.input))
-	copy_computer.memory = make([]int, len(computer.memory))
-	copy_computer.output = make([]int, len(computer.output))
-
-	copy(copy_computer.input, computer.input)
-	copy(copy_computer.memory, computer.memory)
-	copy(copy_computer.output, computer.output)
-
-	return copy_computer
-}
-
-// ----------------------- IntCode Computer Struct End -----------------------
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
 
 // ----------------------- Droid Struct Start -----------------------
 
@@ -248,94 +16,82 @@ type Position struct {
 	y int
 }
 
-func new_Droid(starting_position Position, computer IntCodeComputer) Droid {
+func new_Droid(starting_position Position, computer intcode.IntCodeComputer) Droid {
 	return Droid{computer}
 }
 
 type Droid struct {
-	computer IntCodeComputer
+	computer intcode.IntCodeComputer
 }
 
-func (droid *Droid) run_experimental(output_file string, commands []string) {
+func (droid *Droid) run_experimental(output_file string, session_file string, commands []string) {
 	var index int = 0
 	var commands_sent []string = make([]string, 0)
+	var ascii *intcode.ASCIIComputer = intcode.NewASCII(&droid.computer)
+
+	// Everything read and written, to be replayed later
+	var session intcode.Session
+	droid.computer.Record(&session)
 
 	reader := bufio.NewReader(os.Stdin)
-	for droid.computer.state != "halted" {
+	for droid.computer.State() != intcode.Halted {
 
 		// Run computer
-		droid.computer.run()
+		err := ascii.Run()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 
-		// Read output and clear it
-		output := droid.computer.output
-		fmt.Print(convert_aascii_to_string(output))
-		droid.computer.output = make([]int, 0)
+		// Read output
+		fmt.Print(ascii.Text())
 
 		fmt.Print("> ")
 		var input_from_user string
 		if index < len(commands) {
 			// Take command from already set
 			input_from_user = commands[index]
-			fmt.Print(input_from_user)
+			fmt.Println(input_from_user)
 		} else {
 			// Ask for input from user
 			input_from_user, _ = reader.ReadString('\n')
+			input_from_user = strings.TrimSuffix(input_from_user, "\n")
 		}
 		commands_sent = append(commands_sent, input_from_user)
 
 		// Deal with input
-		if input_from_user == "quit\n" {
+		if input_from_user == "quit" {
 			// Exit out of the computer
 			break
 		}
-		converted_input := convert_string_to_aascii(input_from_user)
-		droid.computer.input = append(droid.computer.input, converted_input...)
+		ascii.WriteLine(input_from_user)
 
 		index = index + 1
 	}
 
-	// Worth saving?
-	if index != len(commands)-1 {
+	// Worth saving when something was typed
+	if len(commands_sent) > len(commands) {
 		file, _ := os.Create(output_file)
 		for _, command := range commands_sent {
-			file.Write([]byte(command))
+			fmt.Fprintln(file, command)
 		}
 		file.Close()
-	}
-}
-
-// ----------------------- Droid Struct End -----------------------
 
-func convert_string_to_aascii(line string) []int {
-	slice_of_runes := []rune(line)
-	var slice_of_ints []int = make([]int, 0)
-
-	for _, element := range slice_of_runes {
-		slice_of_ints = append(slice_of_ints, int(element))
+		err := session.SaveFile(session_file)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 	}
-
-	return slice_of_ints
 }
 
-func convert_aascii_to_string(line []int) string {
-	var slice_of_runes []rune = make([]rune, 0)
-	for _, element := range line {
-		slice_of_runes = append(slice_of_runes, rune(element))
-	}
-
-	return string(slice_of_runes)
-}
+// ----------------------- Droid Struct End -----------------------
 
 func read_commands_from_file(filename string) []string {
-	var commands []string = make([]string, 0)
-
 	file, _ := os.Open(filename)
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		var line string = scanner.Text()
-		commands = append(commands, line+"\n")
-	}
+	defer file.Close()
 
+	commands, _ := intcode.ReadScript(file)
 	return commands
 }
 
@@ -353,17 +109,11 @@ func main() {
 	for scanner.Scan() {
 
 		var line string = scanner.Text()
-		var split []string = strings.Split(line, ",")
-		var values_converted []int = make([]int, 0)
-		for _, code := range split {
-			code_converted, _ := strconv.Atoi(code)
-			values_converted = append(values_converted, code_converted)
-		}
 
-		var computer IntCodeComputer = IntCodeComputer{"booting", make([]int, 0), 0, values_converted, 0, 0, 0, make([]int, 0)}
+		computer, _ := intcode.New(line)
 		var droid Droid = new_Droid(Position{0, 0}, computer)
 
 		var commands []string = read_commands_from_file("solution.txt")
-		droid.run_experimental("output.txt", commands)
+		droid.run_experimental("output.txt", "session.jsonl", commands)
 	}
 }
@@ -0,0 +1,3 @@
+module github.com/Sousa99/AdventOfCode2019
+
+go 1.21
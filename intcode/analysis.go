@@ -0,0 +1,366 @@
+package intcode
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ----------------------- Analysis Struct Start -----------------------
+
+type EdgeKind int
+
+const (
+	// Fallthrough continues on the next instruction
+	Fallthrough EdgeKind = iota
+	// Jump is taken by a jump to an immediate target
+	Jump
+	// Call enters a function, pushing a return address through rb
+	Call
+	// AfterCall resumes once the called function returns
+	AfterCall
+)
+
+var edge_kind_names map[EdgeKind]string = map[EdgeKind]string{
+	Fallthrough: "fallthrough",
+	Jump:        "jump",
+	Call:        "call",
+	AfterCall:   "after call",
+}
+
+func (kind EdgeKind) String() string {
+	return edge_kind_names[kind]
+}
+
+type Edge struct {
+	From int
+	To   int
+	Kind EdgeKind
+}
+
+// BasicBlock is a run of instructions only entered at its start and only
+// left after its last instruction.
+type BasicBlock struct {
+	Start        int
+	Instructions []Instruction
+	Successors   []Edge
+	// Returns is set when the block ends jumping through rb+0, the way
+	// functions return
+	Returns bool
+	// Indirect is set when the block ends jumping to any other address read
+	// from memory, or to a target rewritten at runtime, which cannot be
+	// followed statically
+	Indirect bool
+}
+
+// Function is the code reached from a call target, or from address 0,
+// without following calls.
+type Function struct {
+	Name    string
+	Entry   int
+	Blocks  []int
+	Calls   []int
+	Inputs  []int
+	Outputs []int
+	Returns bool
+}
+
+// SelfModification is an instruction writing to a fixed address inside
+// another instruction.
+type SelfModification struct {
+	Writer      int
+	Target      int
+	Instruction int
+}
+
+// Analysis is the control-flow graph of a program image, split into
+// functions, with the places it reads input, writes output or rewrites
+// its own code.
+type Analysis struct {
+	Listing       Listing
+	Blocks        map[int]*BasicBlock
+	Functions     []Function
+	SelfModifying []SelfModification
+	Inputs        []int
+	Outputs       []int
+}
+
+// Analyze builds the control-flow graph over the instructions Disassemble
+// finds.
+func Analyze(codes []int) Analysis {
+	var listing Listing = Disassemble(codes)
+	var instructions []Instruction = listing.Instructions()
+	var analysis Analysis = Analysis{listing, make(map[int]*BasicBlock), make([]Function, 0), make([]SelfModification, 0), make([]int, 0), make([]int, 0)}
+
+	// Blocks start at labels and right after anything that can jump
+	var leaders map[int]bool = map[int]bool{0: true}
+	for address := range listing.labels {
+		leaders[address] = true
+	}
+	for _, instruction := range instructions {
+		var code int = instruction.Opcode.Code
+		if code == 5 || code == 6 || code == 99 {
+			leaders[instruction.Address+instruction.Opcode.Size()] = true
+		}
+	}
+
+	var current *BasicBlock = nil
+	for index, instruction := range instructions {
+		if current == nil || leaders[instruction.Address] {
+			current = &BasicBlock{instruction.Address, make([]Instruction, 0), make([]Edge, 0), false, false}
+			analysis.Blocks[instruction.Address] = current
+		}
+		current.Instructions = append(current.Instructions, instruction)
+
+		var next int = instruction.Address + instruction.Opcode.Size()
+		var ends bool = index+1 == len(instructions) || instructions[index+1].Address != next || leaders[next]
+		if !ends {
+			continue
+		}
+
+		analysis.link(current, codes)
+		current = nil
+	}
+
+	analysis.find_functions()
+	analysis.find_self_modifications()
+	for _, instruction := range instructions {
+		switch instruction.Opcode.Code {
+		case 3:
+			analysis.Inputs = append(analysis.Inputs, instruction.Address)
+		case 4:
+			analysis.Outputs = append(analysis.Outputs, instruction.Address)
+		}
+	}
+
+	return analysis
+}
+
+// link adds the edges leaving the block after its last instruction.
+func (analysis *Analysis) link(block *BasicBlock, codes []int) {
+	var last Instruction = block.Instructions[len(block.Instructions)-1]
+	var next int = last.Address + last.Opcode.Size()
+	targets, falls_through, known := last.successors(analysis.Listing.written)
+
+	if last.Opcode.Code == 5 || last.Opcode.Code == 6 {
+		block.Returns = last.Opcode.Tag(1) == 2 && last.Arguments[1] == 0
+		block.Indirect = !known
+	}
+
+	// A call pushes its return address right before jumping
+	var return_address int = 0
+	var is_call bool = false
+	if len(block.Instructions) > 1 && !falls_through {
+		return_address, is_call = pushed_return_address(codes, block.Instructions[len(block.Instructions)-2], analysis.Listing.written)
+	}
+
+	for _, target := range targets {
+		if is_call {
+			block.Successors = append(block.Successors, Edge{block.Start, target, Call})
+		} else {
+			block.Successors = append(block.Successors, Edge{block.Start, target, Jump})
+		}
+	}
+	if is_call {
+		block.Successors = append(block.Successors, Edge{block.Start, return_address, AfterCall})
+	}
+	if falls_through && last.Opcode.Code != 99 && analysis.Listing.IsCode(next) {
+		block.Successors = append(block.Successors, Edge{block.Start, next, Fallthrough})
+	}
+}
+
+// find_functions walks from address 0 and every call target, leaving calls
+// to their own functions.
+func (analysis *Analysis) find_functions() {
+	var entries []int = []int{0}
+	var is_entry map[int]bool = map[int]bool{0: true}
+	for _, block := range analysis.Blocks {
+		for _, edge := range block.Successors {
+			if edge.Kind == Call && !is_entry[edge.To] {
+				is_entry[edge.To] = true
+				entries = append(entries, edge.To)
+			}
+		}
+	}
+	sort.Ints(entries)
+
+	for _, entry := range entries {
+		var function Function = Function{fmt.Sprintf("F%04d", entry), entry, make([]int, 0), make([]int, 0), make([]int, 0), make([]int, 0), false}
+		if entry == 0 {
+			function.Name = "main"
+		}
+
+		var visited map[int]bool = map[int]bool{}
+		var calls map[int]bool = map[int]bool{}
+		var pending []int = []int{entry}
+		for len(pending) != 0 {
+			var address int = pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+
+			block, is_block := analysis.Blocks[address]
+			if !is_block || visited[address] {
+				continue
+			}
+			visited[address] = true
+			function.Blocks = append(function.Blocks, address)
+			function.Returns = function.Returns || block.Returns
+
+			for _, instruction := range block.Instructions {
+				switch instruction.Opcode.Code {
+				case 3:
+					function.Inputs = append(function.Inputs, instruction.Address)
+				case 4:
+					function.Outputs = append(function.Outputs, instruction.Address)
+				}
+			}
+			for _, edge := range block.Successors {
+				if edge.Kind == Call {
+					if !calls[edge.To] {
+						calls[edge.To] = true
+						function.Calls = append(function.Calls, edge.To)
+					}
+					continue
+				}
+				pending = append(pending, edge.To)
+			}
+		}
+
+		sort.Ints(function.Blocks)
+		sort.Ints(function.Calls)
+		sort.Ints(function.Inputs)
+		sort.Ints(function.Outputs)
+		analysis.Functions = append(analysis.Functions, function)
+	}
+}
+
+// find_self_modifications looks for position mode writes into instructions.
+func (analysis *Analysis) find_self_modifications() {
+	var owners map[int]int = make(map[int]int)
+	var instructions []Instruction = analysis.Listing.Instructions()
+	for _, instruction := range instructions {
+		for cell := instruction.Address; cell < instruction.Address+instruction.Opcode.Size(); cell++ {
+			owners[cell] = instruction.Address
+		}
+	}
+
+	for _, instruction := range instructions {
+		number_arg, writing_args := arguments_of(instruction.Opcode.Code)
+		for arg_index := number_arg - writing_args; arg_index < number_arg; arg_index++ {
+			if instruction.Opcode.Tag(arg_index) != 0 {
+				continue
+			}
+
+			var target int = instruction.Arguments[arg_index]
+			if owner, is_code := owners[target]; is_code {
+				analysis.SelfModifying = append(analysis.SelfModifying, SelfModification{instruction.Address, target, owner})
+			}
+		}
+	}
+}
+
+// FunctionOf returns the first function containing the block.
+func (analysis Analysis) FunctionOf(block int) (Function, bool) {
+	for _, function := range analysis.Functions {
+		var index int = sort.SearchInts(function.Blocks, block)
+		if index < len(function.Blocks) && function.Blocks[index] == block {
+			return function, true
+		}
+	}
+	return Function{}, false
+}
+
+// Print writes a summary of every function and of the self-modifying code.
+func (analysis Analysis) Print(writer io.Writer) {
+	fmt.Fprintf(writer, "%d blocks, %d functions, %d input and %d output sites\n", len(analysis.Blocks), len(analysis.Functions), len(analysis.Inputs), len(analysis.Outputs))
+
+	for _, function := range analysis.Functions {
+		var calls []string = make([]string, 0, len(function.Calls))
+		for _, call := range function.Calls {
+			calls = append(calls, fmt.Sprintf("F%04d", call))
+		}
+
+		fmt.Fprintf(writer, "%-6s entry %05d  blocks %-3d returns %-5v", function.Name, function.Entry, len(function.Blocks), function.Returns)
+		fmt.Fprintf(writer, "  in %v  out %v  calls [%s]\n", function.Inputs, function.Outputs, strings.Join(calls, " "))
+	}
+
+	for _, modification := range analysis.SelfModifying {
+		fmt.Fprintf(writer, "%05d  writes [%d] inside the instruction at %05d\n", modification.Writer, modification.Target, modification.Instruction)
+	}
+}
+
+// WriteDot exports the graph in Graphviz DOT, one cluster per function. Blocks
+// with input or output are blue, blocks rewritten by the program are red.
+func (analysis Analysis) WriteDot(writer io.Writer) {
+	var modified map[int]bool = make(map[int]bool)
+	for _, modification := range analysis.SelfModifying {
+		modified[modification.Instruction] = true
+	}
+
+	var starts []int = make([]int, 0, len(analysis.Blocks))
+	for start := range analysis.Blocks {
+		starts = append(starts, start)
+	}
+	sort.Ints(starts)
+
+	fmt.Fprintln(writer, "digraph intcode {")
+	fmt.Fprintln(writer, "\tnode [shape=box fontname=monospace style=filled fillcolor=white];")
+
+	var placed map[int]bool = make(map[int]bool)
+	for _, function := range analysis.Functions {
+		fmt.Fprintf(writer, "\tsubgraph cluster_%s {\n\t\tlabel=\"%s\";\n", function.Name, function.Name)
+		for _, start := range function.Blocks {
+			if placed[start] {
+				continue
+			}
+			placed[start] = true
+			fmt.Fprintf(writer, "\t\t%s\n", analysis.dot_node(analysis.Blocks[start], modified))
+		}
+		fmt.Fprintln(writer, "\t}")
+	}
+	for _, start := range starts {
+		if !placed[start] {
+			fmt.Fprintf(writer, "\t%s\n", analysis.dot_node(analysis.Blocks[start], modified))
+		}
+	}
+
+	var styles map[EdgeKind]string = map[EdgeKind]string{
+		Fallthrough: "",
+		Jump:        " [label=\"jump\"]",
+		Call:        " [label=\"call\" style=dashed]",
+		AfterCall:   " [style=dotted]",
+	}
+	for _, start := range starts {
+		for _, edge := range analysis.Blocks[start].Successors {
+			if _, is_block := analysis.Blocks[edge.To]; is_block {
+				fmt.Fprintf(writer, "\tb%d -> b%d%s;\n", edge.From, edge.To, styles[edge.Kind])
+			}
+		}
+	}
+	fmt.Fprintln(writer, "}")
+}
+
+func (analysis Analysis) dot_node(block *BasicBlock, modified map[int]bool) string {
+	var lines []string = make([]string, 0, len(block.Instructions))
+	var color string = "white"
+	for _, instruction := range block.Instructions {
+		lines = append(lines, fmt.Sprintf("%05d  %s\\l", instruction.Address, instruction.format(analysis.Listing.labels)))
+
+		if instruction.Opcode.Code == 3 || instruction.Opcode.Code == 4 {
+			color = "lightblue"
+		}
+	}
+	for _, instruction := range block.Instructions {
+		if modified[instruction.Address] {
+			color = "lightpink"
+		}
+	}
+
+	var header string = ""
+	if label, is_label := analysis.Listing.Label(block.Start); is_label {
+		header = label + ":\\l"
+	}
+	return fmt.Sprintf("b%d [label=\"%s%s\" fillcolor=%s];", block.Start, header, strings.Join(lines, ""), color)
+}
+
+// ----------------------- Analysis Struct End -----------------------
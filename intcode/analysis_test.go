@@ -0,0 +1,97 @@
+package intcode
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The day 5 example telling whether its input is below, equal to or above 8
+func TestAnalyzeDay05Example(t *testing.T) {
+	codes, err := Parse("3,21,1008,21,8,20,1005,20,22,107,8,21,20,1006,20,31,1106,0,36,98,0,0,1002,21,125,20,4,20,1105,1,46,104,999,1105,1,46,1101,1000,1,20,4,20,1105,1,46,98,99")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var analysis Analysis = Analyze(codes)
+
+	var edges map[int][]Edge = map[int][]Edge{
+		0:  {{0, 22, Jump}, {0, 9, Fallthrough}},
+		9:  {{9, 31, Jump}, {9, 16, Fallthrough}},
+		16: {{16, 36, Jump}},
+		22: {{22, 46, Jump}},
+		31: {{31, 46, Jump}},
+		36: {{36, 46, Jump}},
+		46: {},
+	}
+	if len(analysis.Blocks) != len(edges) {
+		t.Fatalf("%d blocks, expected %d", len(analysis.Blocks), len(edges))
+	}
+	for start, expected := range edges {
+		block, is_block := analysis.Blocks[start]
+		if !is_block {
+			t.Fatalf("no block at ' %d '", start)
+		}
+		if !reflect.DeepEqual(block.Successors, expected) {
+			t.Fatalf("block ' %d ' leads to %v, expected %v", start, block.Successors, expected)
+		}
+	}
+
+	var main Function = Function{"main", 0, []int{0, 9, 16, 22, 31, 36, 46}, []int{}, []int{0}, []int{26, 31, 40}, false}
+	if len(analysis.Functions) != 1 || !reflect.DeepEqual(analysis.Functions[0], main) {
+		t.Fatalf("functions %+v, expected only %+v", analysis.Functions, main)
+	}
+	if len(analysis.SelfModifying) != 0 {
+		t.Fatalf("self-modifying %v in a program writing only data", analysis.SelfModifying)
+	}
+
+	var dot bytes.Buffer
+	analysis.WriteDot(&dot)
+	for _, line := range []string{"b0 -> b22 [label=\"jump\"];", "b0 -> b9;", "subgraph cluster_main {"} {
+		if !strings.Contains(dot.String(), line) {
+			t.Fatalf("graph has no ' %s '", line)
+		}
+	}
+}
+
+func TestAnalyzeCalls(t *testing.T) {
+	// Calls the function at 10, which outputs 1 and returns to 7
+	var analysis Analysis = Analyze([]int{21101, 7, 0, 0, 1105, 1, 10, 104, 5, 99, 104, 1, 2106, 0, 0})
+
+	if successors := analysis.Blocks[0].Successors; !reflect.DeepEqual(successors, []Edge{{0, 10, Call}, {0, 7, AfterCall}}) {
+		t.Fatalf("call block leads to %v", successors)
+	}
+	if len(analysis.Functions) != 2 {
+		t.Fatalf("functions %+v, expected main and F0010", analysis.Functions)
+	}
+	if main := analysis.Functions[0]; !reflect.DeepEqual(main.Blocks, []int{0, 7}) || !reflect.DeepEqual(main.Calls, []int{10}) {
+		t.Fatalf("main has blocks %v calling %v", main.Blocks, main.Calls)
+	}
+	if called := analysis.Functions[1]; called.Name != "F0010" || !called.Returns || !reflect.DeepEqual(called.Outputs, []int{10}) {
+		t.Fatalf("called function %+v", called)
+	}
+}
+
+func TestAnalyzeSelfModifying(t *testing.T) {
+	// The day 5 example reading its input into the jump condition
+	var analysis Analysis = Analyze([]int{3, 3, 1105, -1, 9, 1101, 0, 0, 12, 4, 12, 99, 1})
+
+	if !reflect.DeepEqual(analysis.SelfModifying, []SelfModification{{0, 3, 2}}) {
+		t.Fatalf("self-modifying %v, expected the input at 0 into the jump at 2", analysis.SelfModifying)
+	}
+}
+
+func TestAnalyzeRewrittenCode(t *testing.T) {
+	// Both programs rewrite their code before running it
+	var expected map[string]int = map[string]int{"day_05": 10, "day_25": 1}
+	for _, day := range []string{"day_05", "day_25"} {
+		t.Run(day, func(t *testing.T) {
+			var computer IntCodeComputer = load_day(t, day)
+			var analysis Analysis = Analyze(computer.Snapshot().Memory)
+
+			if len(analysis.Outputs) < expected[day] {
+				t.Fatalf("%d output sites, expected at least %d", len(analysis.Outputs), expected[day])
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package intcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ----------------------- ASCII Computer Struct Start -----------------------
+
+// Output values above this are numbers the program reports, not characters
+const MAX_ASCII int = 127
+
+// EncodeASCII turns text into input values, one per character.
+func EncodeASCII(text string) []int {
+	var values []int = make([]int, 0, len(text))
+	for _, character := range text {
+		values = append(values, int(character))
+	}
+	return values
+}
+
+// DecodeASCII splits output values into the text they spell and the values
+// out of the ASCII range, which programs use to report their results.
+func DecodeASCII(values []int) (string, []int) {
+	var builder strings.Builder
+	var results []int = make([]int, 0)
+	for _, value := range values {
+		if value < 0 || value > MAX_ASCII {
+			results = append(results, value)
+			continue
+		}
+		builder.WriteRune(rune(value))
+	}
+	return builder.String(), results
+}
+
+// ASCIIComputer talks to a program that reads and writes lines of text. It
+// runs the computer and takes over its queued output, keeping the text not
+// read yet apart from the numeric results.
+type ASCIIComputer struct {
+	computer *IntCodeComputer
+	text     string
+	results  []int
+}
+
+func NewASCII(computer *IntCodeComputer) *ASCIIComputer {
+	return &ASCIIComputer{computer, "", make([]int, 0)}
+}
+
+func (ascii *ASCIIComputer) Computer() *IntCodeComputer {
+	return ascii.computer
+}
+
+// Results returns every numeric value output so far.
+func (ascii *ASCIIComputer) Results() []int {
+	return ascii.results
+}
+
+// Run runs the computer until it halts or waits for a line.
+func (ascii *ASCIIComputer) Run() error {
+	err := ascii.computer.Run()
+
+	text, results := DecodeASCII(ascii.computer.Output())
+	ascii.text = ascii.text + text
+	ascii.results = append(ascii.results, results...)
+	ascii.computer.ClearOutput()
+
+	return err
+}
+
+// Write queues text as input.
+func (ascii *ASCIIComputer) Write(text string) {
+	ascii.computer.AddInput(EncodeASCII(text)...)
+}
+
+// WriteLine queues a line as input, ending it with a newline.
+func (ascii *ASCIIComputer) WriteLine(line string) {
+	ascii.Write(line + "\n")
+}
+
+// ReadLine returns the next line of text output, without its newline, running
+// the computer when no full line is waiting. Text left when the computer stops
+// mid line, as on a prompt, is returned as a line of its own. Once nothing is
+// left it returns io.EOF, and the computer has halted or wants input.
+func (ascii *ASCIIComputer) ReadLine() (string, error) {
+	if !strings.Contains(ascii.text, "\n") {
+		err := ascii.Run()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var index int = strings.IndexByte(ascii.text, '\n')
+	if index == -1 {
+		if ascii.text == "" {
+			return "", io.EOF
+		}
+		index = len(ascii.text)
+		ascii.text = ascii.text + "\n"
+	}
+
+	var line string = ascii.text[:index]
+	ascii.text = ascii.text[index+1:]
+	return line, nil
+}
+
+// Text returns all text output not read yet.
+func (ascii *ASCIIComputer) Text() string {
+	var text string = ascii.text
+	ascii.text = ""
+	return text
+}
+
+// ----------------------- ASCII Computer Struct End -----------------------
+
+// ----------------------- Transcript Struct Start -----------------------
+
+// Exchange is a turn of a conversation: the text output before the program
+// asked for input, and the line given to it.
+type Exchange struct {
+	Output string
+	Input  string
+}
+
+// RunScript gives the program each line of a script as it asks for input and
+// returns the conversation. A last exchange with no input holds what was
+// output after the script ran out. The script stops early if the program
+// halts.
+func (ascii *ASCIIComputer) RunScript(script []string) ([]Exchange, error) {
+	var transcript []Exchange = make([]Exchange, 0, len(script)+1)
+	for _, line := range script {
+		err := ascii.Run()
+		if err != nil {
+			return transcript, err
+		}
+		if ascii.computer.State() == Halted {
+			break
+		}
+
+		transcript = append(transcript, Exchange{ascii.Text(), line})
+		ascii.WriteLine(line)
+	}
+
+	err := ascii.Run()
+	transcript = append(transcript, Exchange{ascii.Text(), ""})
+	return transcript, err
+}
+
+// ReadScript reads a script, one input line per line.
+func ReadScript(reader io.Reader) ([]string, error) {
+	var script []string = make([]string, 0)
+
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		script = append(script, scanner.Text())
+	}
+	return script, scanner.Err()
+}
+
+// WriteTranscript prints a conversation the way a terminal shows it, every
+// input line after a prompt.
+func WriteTranscript(writer io.Writer, transcript []Exchange) {
+	for index, exchange := range transcript {
+		fmt.Fprint(writer, exchange.Output)
+		if exchange.Input != "" || index != len(transcript)-1 {
+			fmt.Fprintf(writer, "> %s\n", exchange.Input)
+		}
+	}
+}
+
+// ----------------------- Transcript Struct End -----------------------
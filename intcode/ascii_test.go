@@ -0,0 +1,98 @@
+package intcode
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Reads characters and outputs them back until it reads a newline, then
+// outputs 1000 and waits again
+const ASCII_ECHO string = "3,100,4,100,1008,100,10,101,1006,101,0,104,1000,1105,1,0,99"
+
+func TestEncodeDecodeASCII(t *testing.T) {
+	var values []int = EncodeASCII("NOT A J\n")
+	if !reflect.DeepEqual(values, []int{78, 79, 84, 32, 65, 32, 74, 10}) {
+		t.Fatalf("encoded %v", values)
+	}
+
+	text, results := DecodeASCII(append(values, 19355364, 10))
+	if text != "NOT A J\n\n" || !reflect.DeepEqual(results, []int{19355364}) {
+		t.Fatalf("decoded ' %q ' with results %v", text, results)
+	}
+}
+
+func TestASCIIReadLine(t *testing.T) {
+	// Prints two lines, a result and a prompt, then halts
+	codes, err := Assemble(`
+		out #72
+		out #105
+		out #10
+		out #10
+		out #123456
+		out #62
+		hlt
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var computer IntCodeComputer = NewFromCodes(codes)
+	var ascii *ASCIIComputer = NewASCII(&computer)
+	var lines []string = make([]string, 0)
+	for {
+		line, err := ascii.ReadLine()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+
+	if !reflect.DeepEqual(lines, []string{"Hi", "", ">"}) {
+		t.Fatalf("read lines %q", lines)
+	}
+	if !reflect.DeepEqual(ascii.Results(), []int{123456}) || computer.State() != Halted {
+		t.Fatalf("stopped ' %v ' with results %v", computer.State(), ascii.Results())
+	}
+}
+
+func TestASCIIRunScript(t *testing.T) {
+	computer, err := New(ASCII_ECHO)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ascii *ASCIIComputer = NewASCII(&computer)
+	ascii.Write("go")
+	transcript, err := ascii.RunScript([]string{"north", "take sand"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected []Exchange = []Exchange{{"go", "north"}, {"north\n", "take sand"}, {"take sand\n", ""}}
+	if !reflect.DeepEqual(transcript, expected) {
+		t.Fatalf("transcript %q, expected %q", transcript, expected)
+	}
+	if !reflect.DeepEqual(ascii.Results(), []int{1000, 1000}) {
+		t.Fatalf("results %v", ascii.Results())
+	}
+
+	var builder strings.Builder
+	WriteTranscript(&builder, transcript)
+	if builder.String() != "go> north\nnorth\n> take sand\ntake sand\n" {
+		t.Fatalf("printed ' %q '", builder.String())
+	}
+}
+
+func TestReadScript(t *testing.T) {
+	script, err := ReadScript(strings.NewReader("NOT A T\nOR T J\nWALK\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(script, []string{"NOT A T", "OR T J", "WALK"}) {
+		t.Fatalf("script %q", script)
+	}
+}
@@ -0,0 +1,574 @@
+package intcode
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ----------------------- Assembler Struct Start -----------------------
+
+// Assemble translates IntCode assembly into a program image, one statement
+// per line:
+//
+//	; comments run to the end of the line
+//	const NEW_LINE, 10            ; named value
+//	local counter, 0              ; counter is now rb+0
+//	macro print value             ; macros take comma separated arguments
+//	        OUT   value
+//	endm
+//	start:  ARB   #stack          ; labels name the address they are on
+//	        ADD   [size], #0, counter
+//	.loop:  print #'*'            ; labels starting with '.' are unique to
+//	        ADD   counter, #-1, counter  ; each macro expansion
+//	        JNZ   counter, .loop  ; bare values are immediate
+//	        print #NEW_LINE
+//	        HLT
+//	size:   db    3
+//	text:   db    "stars\n", 0
+//	stack:  db    0
+//
+// Arguments are written [x] in position mode, #x in immediate mode and rb+x
+// in relative mode, where x adds and subtracts numbers, 'c' characters and
+// names. Mnemonics and directives are case insensitive. Listings printed by
+// the disassembler assemble back into the program they came from: a number
+// starting a line is the address the statement has to be at.
+func Assemble(source string) ([]int, error) {
+	var assembler assembler = assembler{make(map[string]macro), make(map[string]int), make(map[string]int), 0}
+
+	lines, err := assembler.parse(source)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, err = assembler.expand(lines, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = assembler.layout(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return assembler.encode(lines)
+}
+
+// Format writes a program image in the comma separated form every puzzle
+// input uses.
+func Format(codes []int) string {
+	var values []string = make([]string, 0, len(codes))
+	for _, code := range codes {
+		values = append(values, strconv.Itoa(code))
+	}
+
+	return strings.Join(values, ",")
+}
+
+type source_line struct {
+	number    int
+	address   int
+	label     string
+	operation string
+	operands  []string
+}
+
+type macro struct {
+	parameters []string
+	body       []source_line
+}
+
+type assembler struct {
+	macros     map[string]macro
+	symbols    map[string]int
+	locals     map[string]int
+	expansions int
+}
+
+const MAXIMUM_MACRO_DEPTH int = 64
+
+var address_regex *regexp.Regexp = regexp.MustCompile(`^(\d+)(\s|$)`)
+var label_regex *regexp.Regexp = regexp.MustCompile(`^([A-Za-z_.][\w.]*):`)
+var name_regex *regexp.Regexp = regexp.MustCompile(`^[A-Za-z_.][\w.]*$`)
+var identifier_regex *regexp.Regexp = regexp.MustCompile(`[A-Za-z_.][\w.]*`)
+
+func line_error(line source_line, format string, arguments ...interface{}) error {
+	return fmt.Errorf("line %d: %s", line.number, fmt.Sprintf(format, arguments...))
+}
+
+func (assembler *assembler) parse(source string) ([]source_line, error) {
+	var lines []source_line = make([]source_line, 0)
+	var defining *source_line = nil
+	var definition macro
+
+	for index, text := range strings.Split(source, "\n") {
+		var line source_line = source_line{index + 1, -1, "", "", nil}
+
+		text = strings.TrimSpace(strip_comment(text))
+		if match := address_regex.FindStringSubmatch(text); match != nil {
+			line.address, _ = strconv.Atoi(match[1])
+			text = strings.TrimSpace(text[len(match[1]):])
+		}
+		if match := label_regex.FindStringSubmatch(text); match != nil {
+			line.label = match[1]
+			text = strings.TrimSpace(text[len(match[0]):])
+		}
+
+		if text != "" {
+			var operation_end int = strings.IndexFunc(text, unicode.IsSpace)
+			if operation_end == -1 {
+				operation_end = len(text)
+			}
+
+			line.operation = strings.ToLower(text[:operation_end])
+			if rest := strings.TrimSpace(text[operation_end:]); rest != "" {
+				line.operands = split_operands(rest)
+			}
+		}
+
+		// Macro definitions are kept apart from the code
+		if line.operation == "macro" {
+			if defining != nil {
+				return nil, line_error(line, "macro defined inside macro ' %s '", defining.operands[0])
+			}
+			if len(line.operands) == 0 {
+				return nil, line_error(line, "macro without a name")
+			}
+
+			var header []string = strings.Fields(line.operands[0])
+			var parameters []string = append(header[1:], line.operands[1:]...)
+			line.operands = []string{strings.ToLower(header[0])}
+			definition = macro{parameters, make([]source_line, 0)}
+			defining = &line
+			continue
+		} else if line.operation == "endm" {
+			if defining == nil {
+				return nil, line_error(line, "endm outside of a macro")
+			}
+			assembler.macros[defining.operands[0]] = definition
+			defining = nil
+			continue
+		}
+
+		if defining != nil {
+			definition.body = append(definition.body, line)
+		} else if line.label != "" || line.operation != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if defining != nil {
+		return nil, line_error(*defining, "macro ' %s ' is never closed", defining.operands[0])
+	}
+
+	return lines, nil
+}
+
+// expand replaces every macro call by the body of the macro.
+func (assembler *assembler) expand(lines []source_line, depth int) ([]source_line, error) {
+	var expanded []source_line = make([]source_line, 0, len(lines))
+	for _, line := range lines {
+
+		definition, is_macro := assembler.macros[line.operation]
+		if !is_macro {
+			expanded = append(expanded, line)
+			continue
+		}
+
+		if depth >= MAXIMUM_MACRO_DEPTH {
+			return nil, line_error(line, "macro ' %s ' expands too deep", line.operation)
+		}
+		if len(line.operands) != len(definition.parameters) {
+			return nil, line_error(line, "macro ' %s ' takes %d arguments, got %d", line.operation, len(definition.parameters), len(line.operands))
+		}
+
+		// A label on the call names the first expanded line
+		if line.label != "" {
+			expanded = append(expanded, source_line{line.number, line.address, line.label, "", nil})
+		}
+
+		assembler.expansions = assembler.expansions + 1
+		var suffix string = fmt.Sprintf("_%d", assembler.expansions)
+		var substitute func(string) string = func(text string) string {
+			return replace_unquoted(text, func(name string) string {
+				for index, parameter := range definition.parameters {
+					if name == parameter {
+						return line.operands[index]
+					}
+				}
+				if strings.HasPrefix(name, ".") {
+					return name + suffix
+				}
+				return name
+			})
+		}
+
+		var body []source_line = make([]source_line, 0, len(definition.body))
+		for index, body_line := range definition.body {
+			var new_line source_line = source_line{line.number, -1, substitute(body_line.label), body_line.operation, make([]string, 0, len(body_line.operands))}
+			// The call stands where its first line has to be
+			if index == 0 && line.label == "" {
+				new_line.address = line.address
+			}
+			for _, operand := range body_line.operands {
+				new_line.operands = append(new_line.operands, substitute(operand))
+			}
+			body = append(body, new_line)
+		}
+
+		body, err := assembler.expand(body, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, body...)
+	}
+
+	return expanded, nil
+}
+
+// layout gives every label the address it ends up at.
+func (assembler *assembler) layout(lines []source_line) error {
+	var address int = 0
+	for _, line := range lines {
+
+		if line.address != -1 && line.address != address {
+			return line_error(line, "listed at ' %d ' but assembles at ' %d '", line.address, address)
+		}
+		if line.label != "" {
+			if _, is_set := assembler.symbols[line.label]; is_set {
+				return line_error(line, "name ' %s ' defined twice", line.label)
+			}
+			assembler.symbols[line.label] = address
+		}
+
+		switch line.operation {
+		case "":
+
+		case "const":
+			if len(line.operands) != 2 || !name_regex.MatchString(line.operands[0]) {
+				return line_error(line, "const takes a name and a value")
+			}
+			if _, is_set := assembler.symbols[line.operands[0]]; is_set {
+				return line_error(line, "name ' %s ' defined twice", line.operands[0])
+			}
+			value, err := assembler.evaluate(line.operands[1])
+			if err != nil {
+				return line_error(line, "%v", err)
+			}
+			assembler.symbols[line.operands[0]] = value
+
+		case "local":
+			if len(line.operands) != 2 || !name_regex.MatchString(line.operands[0]) {
+				return line_error(line, "local takes a name and an offset")
+			}
+
+		case "db", "data":
+			for _, operand := range line.operands {
+				if strings.HasPrefix(operand, "\"") {
+					text, err := strconv.Unquote(operand)
+					if err != nil {
+						return line_error(line, "invalid string %s", operand)
+					}
+					address = address + len([]rune(text))
+				} else {
+					address = address + 1
+				}
+			}
+
+		default:
+			code, is_mnemonic := mnemonic_code(line.operation)
+			if !is_mnemonic {
+				return line_error(line, "unknown mnemonic ' %s '", line.operation)
+			}
+			number_arg, _ := arguments_of(code)
+			if len(line.operands) != number_arg {
+				return line_error(line, "%s takes %d arguments, got %d", strings.ToUpper(line.operation), number_arg, len(line.operands))
+			}
+			address = address + 1 + number_arg
+		}
+	}
+
+	return nil
+}
+
+func (assembler *assembler) encode(lines []source_line) ([]int, error) {
+	var codes []int = make([]int, 0)
+	for _, line := range lines {
+
+		switch line.operation {
+		case "", "const":
+
+		case "local":
+			offset, err := assembler.evaluate(line.operands[1])
+			if err != nil {
+				return nil, line_error(line, "%v", err)
+			}
+			assembler.locals[line.operands[0]] = offset
+
+		case "db", "data":
+			for _, operand := range line.operands {
+				if strings.HasPrefix(operand, "\"") {
+					text, _ := strconv.Unquote(operand)
+					for _, character := range text {
+						codes = append(codes, int(character))
+					}
+					continue
+				}
+
+				value, err := assembler.evaluate(operand)
+				if err != nil {
+					return nil, line_error(line, "%v", err)
+				}
+				codes = append(codes, value)
+			}
+
+		default:
+			code, _ := mnemonic_code(line.operation)
+			number_arg, writing_args := arguments_of(code)
+
+			var instruction int = code
+			var arguments []int = make([]int, 0, number_arg)
+			var tag_weight int = 100
+			for arg_index, operand := range line.operands {
+				tag, value, err := assembler.operand(operand)
+				if err != nil {
+					return nil, line_error(line, "%v", err)
+				}
+				if tag == 1 && arg_index >= number_arg-writing_args {
+					return nil, line_error(line, "%v ' %s '", ErrWriteImmediateMode, operand)
+				}
+
+				instruction = instruction + tag*tag_weight
+				tag_weight = tag_weight * 10
+				arguments = append(arguments, value)
+			}
+
+			codes = append(codes, instruction)
+			codes = append(codes, arguments...)
+		}
+	}
+
+	return codes, nil
+}
+
+// operand gives the parameter mode and value of an instruction argument.
+func (assembler *assembler) operand(text string) (int, int, error) {
+	if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+		var inner string = strings.TrimSpace(text[1 : len(text)-1])
+		if is_relative(inner) {
+			return assembler.operand(inner)
+		}
+
+		value, err := assembler.evaluate(inner)
+		return 0, value, err
+	} else if strings.HasPrefix(text, "#") {
+		value, err := assembler.evaluate(text[1:])
+		return 1, value, err
+	} else if is_relative(text) {
+		var offset string = strings.TrimSpace(text[2:])
+		if offset == "" {
+			return 2, 0, nil
+		} else if strings.TrimSpace(offset[1:]) == "" {
+			return 0, 0, fmt.Errorf("missing offset in ' %s '", text)
+		}
+
+		value, err := assembler.evaluate(offset)
+		return 2, value, err
+	} else if offset, is_local := assembler.locals[text]; is_local {
+		return 2, offset, nil
+	}
+
+	value, err := assembler.evaluate(text)
+	return 1, value, err
+}
+
+func is_relative(text string) bool {
+	var lower string = strings.ToLower(text)
+	return lower == "rb" || strings.HasPrefix(lower, "rb+") || strings.HasPrefix(lower, "rb-")
+}
+
+// evaluate adds and subtracts the terms of an expression.
+func (assembler *assembler) evaluate(expression string) (int, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return 0, fmt.Errorf("missing value")
+	}
+
+	var total int = 0
+	var sign int = 1
+	var has_term bool = false
+	var index int = 0
+	for index < len(expression) {
+		var character rune = rune(expression[index])
+		if unicode.IsSpace(character) {
+			index = index + 1
+			continue
+		}
+
+		if character == '+' || character == '-' {
+			if character == '-' {
+				sign = -sign
+			}
+			has_term = false
+			index = index + 1
+			continue
+		}
+
+		// Read a full term
+		var end int = index
+		if character == '\'' {
+			end = closing_quote(expression, index)
+			if end == -1 {
+				return 0, fmt.Errorf("unclosed character in ' %s '", expression)
+			}
+			end = end + 1
+		} else {
+			for end < len(expression) && !strings.ContainsRune("+- \t", rune(expression[end])) {
+				end = end + 1
+			}
+		}
+
+		value, err := assembler.term(expression[index:end])
+		if err != nil {
+			return 0, err
+		}
+		if !multiplication_fits(sign, value) || !addition_fits(total, sign*value) {
+			return 0, fmt.Errorf("' %s ' overflows", expression)
+		}
+		total = total + sign*value
+		sign = 1
+		has_term = true
+		index = end
+	}
+
+	if !has_term {
+		return 0, fmt.Errorf("missing value in ' %s '", expression)
+	}
+	return total, nil
+}
+
+func (assembler *assembler) term(text string) (int, error) {
+	if strings.HasPrefix(text, "'") {
+		value, _, tail, err := strconv.UnquoteChar(text[1:len(text)-1], '\'')
+		if err != nil || tail != "" {
+			return 0, fmt.Errorf("invalid character %s", text)
+		}
+		return int(value), nil
+	}
+
+	if value, err := strconv.Atoi(text); err == nil {
+		return value, nil
+	}
+
+	if value, is_set := assembler.symbols[text]; is_set {
+		return value, nil
+	}
+	if value, is_local := assembler.locals[text]; is_local {
+		return value, nil
+	}
+
+	return 0, fmt.Errorf("unknown name ' %s '", text)
+}
+
+func mnemonic_code(operation string) (int, bool) {
+	for code, mnemonic := range Mnemonics {
+		if strings.EqualFold(mnemonic, operation) {
+			return code, true
+		}
+	}
+
+	return 0, false
+}
+
+// strip_comment drops everything after a ';' that is not inside a string or
+// character.
+func strip_comment(text string) string {
+	var quote rune = 0
+	var escaped bool = false
+	for index, character := range text {
+		if escaped {
+			escaped = false
+		} else if quote != 0 && character == '\\' {
+			escaped = true
+		} else if quote != 0 && character == quote {
+			quote = 0
+		} else if quote == 0 && (character == '"' || character == '\'') {
+			quote = character
+		} else if quote == 0 && character == ';' {
+			return text[:index]
+		}
+	}
+
+	return text
+}
+
+// closing_quote returns the index of the quote closing the one at start, or
+// -1 when it is never closed.
+func closing_quote(text string, start int) int {
+	var escaped bool = false
+	for index := start + 1; index < len(text); index++ {
+		if escaped {
+			escaped = false
+		} else if text[index] == '\\' {
+			escaped = true
+		} else if text[index] == text[start] {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// replace_unquoted replaces the names outside strings and characters.
+func replace_unquoted(text string, replace func(string) string) string {
+	var builder strings.Builder
+	var index int = 0
+	for index < len(text) {
+		var start int = strings.IndexAny(text[index:], "\"'")
+		if start == -1 {
+			break
+		}
+		start = index + start
+
+		var end int = closing_quote(text, start)
+		if end == -1 {
+			break
+		}
+		builder.WriteString(identifier_regex.ReplaceAllStringFunc(text[index:start], replace))
+		builder.WriteString(text[start : end+1])
+		index = end + 1
+	}
+	builder.WriteString(identifier_regex.ReplaceAllStringFunc(text[index:], replace))
+
+	return builder.String()
+}
+
+// split_operands splits on the commas that are not inside a string or
+// character.
+func split_operands(text string) []string {
+	var operands []string = make([]string, 0)
+	var quote rune = 0
+	var escaped bool = false
+	var start int = 0
+	for index, character := range text {
+		if escaped {
+			escaped = false
+		} else if quote != 0 && character == '\\' {
+			escaped = true
+		} else if quote != 0 && character == quote {
+			quote = 0
+		} else if quote == 0 && (character == '"' || character == '\'') {
+			quote = character
+		} else if quote == 0 && character == ',' {
+			operands = append(operands, strings.TrimSpace(text[start:index]))
+			start = index + 1
+		}
+	}
+	operands = append(operands, strings.TrimSpace(text[start:]))
+
+	return operands
+}
+
+// ----------------------- Assembler Struct End -----------------------
@@ -0,0 +1,118 @@
+package intcode
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestAssemble(t *testing.T) {
+	var tests []struct {
+		name   string
+		source string
+		codes  []int
+	} = []struct {
+		name   string
+		source string
+		codes  []int
+	}{
+		{"modes", "ADD [4], #3, rb+1\nOUT rb-2\nHLT", []int{21001, 4, 3, 1, 204, -2, 99}},
+		{"bare values are immediate", "OUT 7", []int{104, 7}},
+		{"labels", "start: JNZ #1, end\nJZ #0, start\nend: HLT", []int{1105, 1, 6, 1106, 0, 0, 99}},
+		{"expressions", "const TEN, 10\nOUT #TEN - 3 + 'a'\ndb end+1\nend: db 0", []int{104, 104, 4, 0}},
+		{"characters", "OUT #'\\n'\nOUT #'\\''\nOUT #';' ; comment", []int{104, 10, 104, 39, 104, 59}},
+		{"strings", "db \"hi\\n\", 0\ndb \"a;b\"", []int{104, 105, 10, 0, 97, 59, 98}},
+		{"locals", "local counter, 3\nADD counter, #-1, counter", []int{21201, 3, -1, 3}},
+		{"macro arguments", "macro print value\nOUT value\nendm\nprint #5\nprint [7]", []int{104, 5, 4, 7}},
+		{"macro arguments not in literals", "macro m a\nOUT #'a'\nendm\nm #5\nHLT", []int{104, 97, 99}},
+		{"macro arguments not in strings", "macro m a\ndb \"a\", a\nendm\nm 5", []int{97, 5}},
+		{
+			"macro labels are unique",
+			"macro spin\n.loop: JNZ #0, .loop\nendm\nspin\nfirst: spin",
+			[]int{1105, 0, 0, 1105, 0, 3},
+		},
+		{"nested macros", "macro one\nOUT #1\nendm\nmacro two\none\none\nendm\ntwo", []int{104, 1, 104, 1}},
+		{"relative offsets", "OUT rb\nOUT rb-2\nOUT [rb+3]", []int{204, 0, 204, -2, 204, 3}},
+		{"smallest value", "db -9223372036854775807-1", []int{-9223372036854775808}},
+		{"listed addresses", "00000          OUT  #1\n00002  L0002: DATA 99", []int{104, 1, 99}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			codes, err := Assemble(test.source)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(codes, test.codes) {
+				t.Fatalf("assembled %v, expected %v", codes, test.codes)
+			}
+		})
+	}
+}
+
+func TestAssembleErrors(t *testing.T) {
+	var tests []struct {
+		name   string
+		source string
+		err    string
+	} = []struct {
+		name   string
+		source string
+		err    string
+	}{
+		{"unknown mnemonic", "JMP #0", "line 1: unknown mnemonic ' jmp '"},
+		{"argument count", "OUT #1, #2", "line 1: OUT takes 1 arguments, got 2"},
+		{"immediate write", "HLT\nADD #1, #2, #3", "line 2: " + ErrWriteImmediateMode.Error() + " ' #3 '"},
+		{"unknown name", "JNZ #1, nowhere", "line 1: unknown name ' nowhere '"},
+		{"name defined twice", "a: HLT\na: HLT", "line 2: name ' a ' defined twice"},
+		{"long character", "db 'ab'", "line 1: invalid character 'ab'"},
+		{"unclosed character", "OUT #'a", "line 1: unclosed character in ' 'a '"},
+		{"unclosed macro", "macro m\nOUT #1", "line 1: macro ' m ' is never closed"},
+		{"endm outside macro", "endm", "line 1: endm outside of a macro"},
+		{"macro argument count", "macro m a\nOUT a\nendm\nm", "line 4: macro ' m ' takes 1 arguments, got 0"},
+		{"recursive macro", "macro m\nm\nendm\nm", "line 4: macro ' m ' expands too deep"},
+		{"listed address", "00000  OUT #1\n00003  HLT", "line 2: listed at ' 3 ' but assembles at ' 2 '"},
+		{"empty relative offset", "OUT rb-", "line 1: missing offset in ' rb- '"},
+		{"trailing operator", "OUT #5 +", "line 1: missing value in ' 5 + '"},
+		{"overflow", "OUT #9223372036854775807+1", "line 1: ' 9223372036854775807+1 ' overflows"},
+		{"negative overflow", "OUT #-9223372036854775807-2", "line 1: ' -9223372036854775807-2 ' overflows"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			codes, err := Assemble(test.source)
+			if err == nil || err.Error() != test.err {
+				t.Fatalf("assembled %v with ' %v ', expected ' %s '", codes, err, test.err)
+			}
+		})
+	}
+}
+
+// Every puzzle program disassembles into a listing that assembles back into
+// the same program.
+func TestAssembleListings(t *testing.T) {
+	var days []string = []string{"day_02", "day_05", "day_07", "day_09", "day_11", "day_13", "day_15", "day_17", "day_19", "day_21", "day_23", "day_25"}
+	for _, day := range days {
+		t.Run(day, func(t *testing.T) {
+			content, err := os.ReadFile("../" + day + "/input.txt")
+			if err != nil {
+				t.Skip(err)
+			}
+			codes, err := Parse(string(content))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var listing bytes.Buffer
+			Disassemble(codes).Print(&listing)
+			assembled, err := Assemble(listing.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(assembled, codes) {
+				t.Fatalf("assembled listing differs from the program")
+			}
+		})
+	}
+}
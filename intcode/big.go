@@ -0,0 +1,321 @@
+package intcode
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ----------------------- Big IntCode Computer Struct Start -----------------------
+
+// BigComputer runs IntCode with arbitrary precision values, so arithmetic
+// never overflows. Addresses, the instruction pointer and the relative base
+// still have to fit an int, or the instruction fails with
+// ErrAddressOutOfRange. It is much slower than IntCodeComputer.
+type BigComputer struct {
+	state             State
+	input             []*big.Int
+	input_pointer     int
+	memory            map[int]*big.Int
+	memory_size       int
+	memory_pointer    int
+	relative_pointer  int
+	output            []*big.Int
+	fault             error
+	instruction_count int
+}
+
+// ParseBig converts a comma separated IntCode program into arbitrary
+// precision codes.
+func ParseBig(program string) ([]*big.Int, error) {
+	var split []string = strings.Split(strings.TrimSpace(program), ",")
+	var codes []*big.Int = make([]*big.Int, 0, len(split))
+	for _, code := range split {
+		code_converted, is_number := new(big.Int).SetString(strings.TrimSpace(code), 10)
+		if !is_number {
+			return nil, fmt.Errorf("code value not recognized: ' %s '", code)
+		}
+		codes = append(codes, code_converted)
+	}
+
+	return codes, nil
+}
+
+func NewBig(program string) (BigComputer, error) {
+	codes, err := ParseBig(program)
+	if err != nil {
+		return BigComputer{}, err
+	}
+
+	return NewBigFromCodes(codes), nil
+}
+
+// NewBigFromCodes builds a computer whose memory is a copy of codes.
+func NewBigFromCodes(codes []*big.Int) BigComputer {
+	var memory map[int]*big.Int = make(map[int]*big.Int, len(codes))
+	for address, code := range codes {
+		memory[address] = new(big.Int).Set(code)
+	}
+
+	return BigComputer{state: Booting, input: make([]*big.Int, 0), memory: memory, memory_size: len(codes), output: make([]*big.Int, 0)}
+}
+
+func (computer *BigComputer) State() State {
+	return computer.state
+}
+
+func (computer *BigComputer) Pointer() int {
+	return computer.memory_pointer
+}
+
+func (computer *BigComputer) RelativeBase() int {
+	return computer.relative_pointer
+}
+
+func (computer *BigComputer) InstructionCount() int {
+	return computer.instruction_count
+}
+
+func (computer *BigComputer) AddInput(values ...*big.Int) {
+	for _, value := range values {
+		computer.input = append(computer.input, new(big.Int).Set(value))
+	}
+}
+
+func (computer *BigComputer) Output() []*big.Int {
+	return computer.output
+}
+
+func (computer *BigComputer) ClearOutput() {
+	computer.output = make([]*big.Int, 0)
+}
+
+func (computer *BigComputer) ReadMemory(position int) (*big.Int, error) {
+	value, err := computer.read(position)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(value), nil
+}
+
+func (computer *BigComputer) WriteMemory(position int, value *big.Int) error {
+	return computer.write(position, new(big.Int).Set(value))
+}
+
+// to_address checks a value can be used as a memory address.
+func to_address(value *big.Int) (int, error) {
+	if !value.IsInt64() {
+		return 0, fmt.Errorf("%w: ' %v '", ErrAddressOutOfRange, value)
+	}
+
+	var address int = int(value.Int64())
+	if address < 0 {
+		return 0, fmt.Errorf("%w: ' %d '", ErrNegativeAddress, address)
+	}
+	return address, nil
+}
+
+// Cells are never changed in place, so values read can be shared freely.
+func (computer *BigComputer) read(position int) (*big.Int, error) {
+	if position < 0 {
+		return nil, fmt.Errorf("%w: ' %d '", ErrNegativeAddress, position)
+	}
+	if position >= computer.memory_size {
+		computer.memory_size = position + 1
+	}
+
+	value, is_set := computer.memory[position]
+	if !is_set {
+		return new(big.Int), nil
+	}
+	return value, nil
+}
+
+func (computer *BigComputer) write(position int, value *big.Int) error {
+	if position < 0 {
+		return fmt.Errorf("%w: ' %d '", ErrNegativeAddress, position)
+	}
+	if position >= computer.memory_size {
+		computer.memory_size = position + 1
+	}
+
+	computer.memory[position] = value
+	return nil
+}
+
+// parameter resolves an argument read by the instruction to its value.
+func (computer *BigComputer) parameter(opcode Opcode, arg_index int) (*big.Int, error) {
+	argument, err := computer.read(computer.memory_pointer + 1 + arg_index)
+	if err != nil {
+		return nil, err
+	}
+
+	switch opcode.Tag(arg_index) {
+	case 1:
+		return argument, nil
+	case 2:
+		address, err := to_address(new(big.Int).Add(big.NewInt(int64(computer.relative_pointer)), argument))
+		if err != nil {
+			return nil, err
+		}
+		return computer.read(address)
+	default:
+		address, err := to_address(argument)
+		if err != nil {
+			return nil, err
+		}
+		return computer.read(address)
+	}
+}
+
+// destination resolves an argument written by the instruction to its address.
+func (computer *BigComputer) destination(opcode Opcode, arg_index int) (int, error) {
+	argument, err := computer.read(computer.memory_pointer + 1 + arg_index)
+	if err != nil {
+		return 0, err
+	}
+
+	switch opcode.Tag(arg_index) {
+	case 1:
+		return 0, ErrWriteImmediateMode
+	case 2:
+		return to_address(new(big.Int).Add(big.NewInt(int64(computer.relative_pointer)), argument))
+	default:
+		return to_address(argument)
+	}
+}
+
+// Step executes a single instruction, with the same contract as
+// IntCodeComputer.Step.
+func (computer *BigComputer) Step() error {
+	switch computer.state {
+	case Halted:
+		return nil
+	case Faulted:
+		return computer.fault
+	}
+	computer.state = Running
+
+	var pointer int = computer.memory_pointer
+	var instruction int = 0
+	value, err := computer.read(pointer)
+	if err == nil {
+		if value.IsInt64() {
+			instruction = int(value.Int64())
+			err = computer.execute(instruction)
+		} else {
+			err = fmt.Errorf("%w: ' %v '", ErrUnknownOpcode, value)
+		}
+	}
+
+	if err != nil {
+		// Keep pointing at the failing instruction
+		computer.memory_pointer = pointer
+		computer.state = Faulted
+		computer.fault = &InstructionError{err, pointer, instruction}
+		return computer.fault
+	}
+
+	if computer.state != AwaitingInput {
+		computer.instruction_count = computer.instruction_count + 1
+	}
+	return nil
+}
+
+func (computer *BigComputer) execute(instruction int) error {
+	current_opcode, err := GetOpcode(instruction)
+	if err != nil {
+		return err
+	}
+
+	var number_arg, writing_args int = arguments_of(current_opcode.Code)
+	var values []*big.Int = make([]*big.Int, 0, number_arg)
+	for arg_index := 0; arg_index < number_arg-writing_args; arg_index++ {
+		value, err := computer.parameter(current_opcode, arg_index)
+		if err != nil {
+			return err
+		}
+		values = append(values, value)
+	}
+	var target int = 0
+	if writing_args != 0 {
+		target, err = computer.destination(current_opcode, number_arg-1)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch current_opcode.Code {
+	// Halting
+	case 99:
+		computer.state = Halted
+
+	// Addition
+	case 1:
+		err = computer.write(target, new(big.Int).Add(values[0], values[1]))
+		computer.memory_pointer = computer.memory_pointer + 4
+
+	// Multiplication
+	case 2:
+		err = computer.write(target, new(big.Int).Mul(values[0], values[1]))
+		computer.memory_pointer = computer.memory_pointer + 4
+
+	// Input
+	case 3:
+		if computer.input_pointer >= len(computer.input) {
+			// Computer must wait for more input
+			computer.state = AwaitingInput
+		} else {
+			err = computer.write(target, computer.input[computer.input_pointer])
+			computer.input_pointer = computer.input_pointer + 1
+			computer.memory_pointer = computer.memory_pointer + 2
+		}
+
+	// Output
+	case 4:
+		computer.output = append(computer.output, values[0])
+		computer.memory_pointer = computer.memory_pointer + 2
+
+	// Jump if True and Jump if False
+	case 5, 6:
+		if (values[0].Sign() != 0) == (current_opcode.Code == 5) {
+			computer.memory_pointer, err = to_address(values[1])
+		} else {
+			computer.memory_pointer = computer.memory_pointer + 3
+		}
+
+	// Less than and Equals
+	case 7, 8:
+		var comparison int = values[0].Cmp(values[1])
+		if (current_opcode.Code == 7 && comparison < 0) || (current_opcode.Code == 8 && comparison == 0) {
+			err = computer.write(target, big.NewInt(1))
+		} else {
+			err = computer.write(target, big.NewInt(0))
+		}
+		computer.memory_pointer = computer.memory_pointer + 4
+
+	// Adjust relative base
+	case 9:
+		var base *big.Int = new(big.Int).Add(big.NewInt(int64(computer.relative_pointer)), values[0])
+		if !base.IsInt64() {
+			return fmt.Errorf("%w: relative base ' %v '", ErrAddressOutOfRange, base)
+		}
+		computer.relative_pointer = int(base.Int64())
+		computer.memory_pointer = computer.memory_pointer + 2
+	}
+
+	return err
+}
+
+// Run executes instructions until the program halts, needs more input or
+// fails, in which case the failure is returned.
+func (computer *BigComputer) Run() error {
+	err := computer.Step()
+	for err == nil && computer.state == Running {
+		err = computer.Step()
+	}
+
+	return err
+}
+
+// ----------------------- Big IntCode Computer Struct End -----------------------
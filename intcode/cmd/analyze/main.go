@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// Prints the functions, I/O sites and self-modifying code of an IntCode
+// program, optionally writing its control-flow graph for Graphviz:
+//
+//	go run ./intcode/cmd/analyze -dot day_25.dot day_25/input.txt
+//	dot -Tsvg day_25.dot -o day_25.svg
+func main() {
+	var dot_name *string = flag.String("dot", "", "file to write the graph to, - for standard output")
+	flag.Parse()
+
+	var file_name string = "input.txt"
+	if flag.NArg() > 0 {
+		file_name = flag.Arg(0)
+	}
+
+	content, err := os.ReadFile(file_name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	codes, err := intcode.Parse(string(content))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var analysis intcode.Analysis = intcode.Analyze(codes)
+	if *dot_name == "-" {
+		analysis.WriteDot(os.Stdout)
+		return
+	}
+
+	analysis.Print(os.Stdout)
+	if *dot_name != "" {
+		file, err := os.Create(*dot_name)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer file.Close()
+
+		analysis.WriteDot(file)
+	}
+}
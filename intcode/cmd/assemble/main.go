@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// Assembles an IntCode program into the comma separated format read by every
+// day, printing it or saving it when an output file is given:
+//
+//	go run ./intcode/cmd/assemble program.asm [input.txt]
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: assemble <source> [output]")
+		os.Exit(1)
+	}
+
+	content, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	codes, err := intcode.Assemble(string(content))
+	if err != nil {
+		fmt.Printf("%s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+
+	var program string = intcode.Format(codes)
+	if len(os.Args) < 3 {
+		fmt.Println(program)
+		return
+	}
+
+	err = os.WriteFile(os.Args[2], []byte(program+"\n"), 0644)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
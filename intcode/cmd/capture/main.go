@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// Prints the events of a network capture involving a node, or of a kind, and
+// a summary of the traffic of every node in them. Captures are in the format
+// documented on intcode.PacketCapture:
+//
+//	go run . 42 packets.jsonl                  (in day_23)
+//	go run ./intcode/cmd/capture -node 255 day_23/packets.jsonl
+func main() {
+	var node *int = flag.Int("node", -1, "only events sent by or to this address")
+	var kind *string = flag.String("kind", "", "only events of this kind: send, drop, nat, deliver or receive")
+	var summary_only *bool = flag.Bool("summary", false, "only print the summary")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Println("Usage: capture [-node address] [-kind kind] [-summary] <capture>")
+		os.Exit(1)
+	}
+
+	file, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	events, err := intcode.ReadCapture(file)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var selected []intcode.PacketEvent = make([]intcode.PacketEvent, 0)
+	for _, event := range events {
+		if *node != -1 && event.Packet.Source != *node && event.Packet.Destination != *node {
+			continue
+		}
+		if *kind != "" && event.Kind != *kind {
+			continue
+		}
+		selected = append(selected, event)
+	}
+
+	if !*summary_only {
+		var log *intcode.PacketLog = intcode.NewPacketLog(os.Stdout)
+		for _, event := range selected {
+			log.Observe(event)
+		}
+		fmt.Println()
+	}
+
+	fmt.Printf("' %d ' of ' %d ' events\n", len(selected), len(events))
+	fmt.Printf("%7s %6s %7s %6s %8s %8s\n", "node", "sent", "dropped", "to nat", "from nat", "received")
+	for _, traffic := range intcode.SummarizeCapture(selected) {
+		fmt.Printf("%7d %6d %7d %6d %8d %8d\n", traffic.Address, traffic.Sent, traffic.Dropped, traffic.ToNAT, traffic.FromNAT, traffic.Received)
+	}
+}
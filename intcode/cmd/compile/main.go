@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// Compiles an IntCode program into Go source declaring a program to be run
+// with RunCompiled, printing it or saving it when an output file is given:
+//
+//	go run ./intcode/cmd/compile -package main -name beam day_19/input.txt [beam.go]
+func main() {
+	var package_name *string = flag.String("package", "main", "package of the generated source")
+	var name *string = flag.String("name", "program", "name of the compiled program variable")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Println("Usage: compile [-package name] [-name name] <input> [output]")
+		os.Exit(1)
+	}
+
+	content, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	codes, err := intcode.Parse(string(content))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	source, err := intcode.Compile(codes, *package_name, *name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if flag.NArg() < 2 {
+		fmt.Print(string(source))
+		return
+	}
+
+	err = os.WriteFile(flag.Arg(1), source, 0644)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
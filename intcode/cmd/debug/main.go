@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// Debugs an IntCode program interactively, optionally queueing input values
+// before it starts:
+//
+//	go run ./intcode/cmd/debug day_15/input.txt [values...]
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: debug <input> [values...]")
+		os.Exit(1)
+	}
+
+	content, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	computer, err := intcode.New(string(content))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	for _, argument := range os.Args[2:] {
+		value, err := strconv.Atoi(argument)
+		if err != nil {
+			fmt.Printf("Input value not recognized: ' %s '\n", argument)
+			os.Exit(1)
+		}
+		computer.AddInput(value)
+	}
+
+	var debugger *intcode.Debugger = intcode.NewDebugger(&computer)
+	debugger.Repl(os.Stdin, os.Stdout)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// Prints an annotated listing of an IntCode program:
+//
+//	go run ./intcode/cmd/disassemble day_13/input.txt
+func main() {
+	var file_name string = "input.txt"
+	if len(os.Args) > 1 {
+		file_name = os.Args[1]
+	}
+
+	content, err := os.ReadFile(file_name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	codes, err := intcode.Parse(string(content))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	var listing intcode.Listing = intcode.Disassemble(codes)
+	listing.Print(os.Stdout)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// Runs an IntCode program with the given input values and prints where it
+// spent its instructions, optionally writing the full trace too:
+//
+//	go run ./intcode/cmd/profile -trace trace.jsonl day_09/input.txt 2
+//
+// A trace file ending in .bin is written in the binary format.
+func main() {
+	var trace_name *string = flag.String("trace", "", "file to write the execution trace to")
+	var top *int = flag.Int("top", 10, "number of hot addresses to report")
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		fmt.Println("Usage: profile [-trace file] [-top count] <input> [values...]")
+		os.Exit(1)
+	}
+
+	content, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	computer, err := intcode.New(string(content))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	for _, argument := range flag.Args()[1:] {
+		value, err := strconv.Atoi(argument)
+		if err != nil {
+			fmt.Printf("Input value not recognized: ' %s '\n", argument)
+			os.Exit(1)
+		}
+		computer.AddInput(value)
+	}
+
+	var profiler *intcode.Profiler = intcode.NewProfiler()
+	var tracers intcode.Tracers = intcode.Tracers{profiler}
+	var trace_writer *bufio.Writer = nil
+	if *trace_name != "" {
+		file, err := os.Create(*trace_name)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer file.Close()
+
+		trace_writer = bufio.NewWriter(file)
+		if strings.HasSuffix(*trace_name, ".bin") {
+			tracers = append(tracers, intcode.NewBinaryTracer(trace_writer))
+		} else {
+			tracers = append(tracers, intcode.NewJSONTracer(trace_writer))
+		}
+	}
+	computer.SetTracer(tracers)
+
+	err = computer.Run()
+	if trace_writer != nil {
+		trace_writer.Flush()
+	}
+	if err != nil {
+		fmt.Println(err)
+	}
+
+	fmt.Printf("Finished ' %v ' with output: %v\n", computer.State(), computer.Output())
+	profiler.Report(os.Stdout, *top)
+}
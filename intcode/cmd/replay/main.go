@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+// Replays a session recorded by a puzzle host against its program and reports
+// the first event that did not happen again:
+//
+//	go run ./intcode/cmd/replay day_25/session.jsonl day_25/input.txt
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("Usage: replay <session> [program]")
+		os.Exit(1)
+	}
+
+	var file_name string = "input.txt"
+	if len(os.Args) > 2 {
+		file_name = os.Args[2]
+	}
+
+	session, err := intcode.LoadSessionFile(os.Args[1])
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	content, err := os.ReadFile(file_name)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	computer, err := intcode.New(string(content))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	divergence, err := intcode.Replay(&computer, session)
+	if err != nil {
+		fmt.Println(err)
+	}
+	if divergence != nil {
+		fmt.Printf("Diverged at %v\n", *divergence)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Replayed ' %d ' events in ' %d ' instructions, left ' %v '\n", len(session.Events), computer.InstructionCount(), computer.State())
+}
@@ -0,0 +1,130 @@
+package compiled
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/Sousa99/AdventOfCode2019/intcode"
+)
+
+func load_day(t testing.TB, day string) intcode.IntCodeComputer {
+	content, err := os.ReadFile("../../" + day + "/input.txt")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	computer, err := intcode.New(string(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return computer
+}
+
+// compare runs both computers the same way and expects them to end up in
+// exactly the same state.
+func compare(t *testing.T, interpreted intcode.IntCodeComputer, compiled intcode.IntCodeComputer, program *intcode.CompiledProgram, inputs ...[]int) {
+	for round, input := range inputs {
+		interpreted.AddInput(input...)
+		compiled.AddInput(input...)
+
+		expected_err := interpreted.Run()
+		err := compiled.RunCompiled(program)
+		if (err == nil) != (expected_err == nil) {
+			t.Fatalf("round %d: got %v, expected %v", round, err, expected_err)
+		}
+
+		if expected, got := interpreted.Snapshot(), compiled.Snapshot(); !reflect.DeepEqual(expected, got) {
+			t.Fatalf("round %d: compiled run stopped ' %v ' at ' %d ' after %d instructions with output %v, expected ' %v ' at ' %d ' after %d with %v",
+				round, got.State, got.Pointer, got.InstructionCount, got.Output, expected.State, expected.Pointer, expected.InstructionCount, expected.Output)
+		}
+	}
+}
+
+func TestCompiledDay02(t *testing.T) {
+	var computer intcode.IntCodeComputer = load_day(t, "day_02")
+
+	// The noun and verb patch the first instruction, which is then interpreted
+	computer.WriteMemory(1, 12)
+	computer.WriteMemory(2, 2)
+	compare(t, computer, intcode.MakeDeepCopy(computer), day_02)
+}
+
+func TestCompiledDay09(t *testing.T) {
+	for _, input := range []int{1, 2} {
+		var computer intcode.IntCodeComputer = load_day(t, "day_09")
+		compare(t, computer, intcode.MakeDeepCopy(computer), day_09, []int{input})
+	}
+}
+
+func TestCompiledDay15(t *testing.T) {
+	var computer intcode.IntCodeComputer = load_day(t, "day_15")
+	compare(t, computer, intcode.MakeDeepCopy(computer), day_15, nil, []int{1}, []int{2}, []int{3}, []int{4}, []int{4}, []int{1})
+}
+
+func TestCompiledDay19(t *testing.T) {
+	for x := 0; x < 10; x++ {
+		for y := 0; y < 10; y++ {
+			var computer intcode.IntCodeComputer = load_day(t, "day_19")
+			compare(t, computer, intcode.MakeDeepCopy(computer), day_19, []int{x * 3, y * 4})
+		}
+	}
+}
+
+// Runs each program on the interpreter and compiled, on the same inputs.
+func bench(b *testing.B, day string, program *intcode.CompiledProgram, compiled bool, input func(iteration int) []int) {
+	var base intcode.IntCodeComputer = load_day(b, day)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		var computer intcode.IntCodeComputer = intcode.MakeDeepCopy(base)
+		computer.AddInput(input(iteration)...)
+
+		var err error
+		if compiled {
+			err = computer.RunCompiled(program)
+		} else {
+			err = computer.Run()
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func day_09_input(iteration int) []int {
+	return []int{2}
+}
+
+func day_15_input(iteration int) []int {
+	return []int{iteration%4 + 1, (iteration/4)%4 + 1}
+}
+
+func day_19_input(iteration int) []int {
+	return []int{iteration % 50, iteration / 50 % 50}
+}
+
+func BenchmarkDay09Interpreter(b *testing.B) {
+	bench(b, "day_09", day_09, false, day_09_input)
+}
+
+func BenchmarkDay09Compiled(b *testing.B) {
+	bench(b, "day_09", day_09, true, day_09_input)
+}
+
+func BenchmarkDay15Interpreter(b *testing.B) {
+	bench(b, "day_15", day_15, false, day_15_input)
+}
+
+func BenchmarkDay15Compiled(b *testing.B) {
+	bench(b, "day_15", day_15, true, day_15_input)
+}
+
+func BenchmarkDay19Interpreter(b *testing.B) {
+	bench(b, "day_19", day_19, false, day_19_input)
+}
+
+func BenchmarkDay19Compiled(b *testing.B) {
+	bench(b, "day_19", day_19, true, day_19_input)
+}
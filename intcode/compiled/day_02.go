@@ -0,0 +1,291 @@
+// Code generated by intcode.Compile. DO NOT EDIT.
+
+package compiled
+
+import "github.com/Sousa99/AdventOfCode2019/intcode"
+
+var day_02 *intcode.CompiledProgram = intcode.NewCompiledProgram(day_02_image, day_02_addresses, run_day_02)
+
+var day_02_image []int = []int{
+	1, 0, 0, 3, 1, 1, 2, 3, 1, 3, 4, 3, 1, 5, 0, 3,
+	2, 1, 9, 19, 1, 19, 5, 23, 2, 23, 13, 27, 1, 10, 27, 31,
+	2, 31, 6, 35, 1, 5, 35, 39, 1, 39, 10, 43, 2, 9, 43, 47,
+	1, 47, 5, 51, 2, 51, 9, 55, 1, 13, 55, 59, 1, 13, 59, 63,
+	1, 6, 63, 67, 2, 13, 67, 71, 1, 10, 71, 75, 2, 13, 75, 79,
+	1, 5, 79, 83, 2, 83, 9, 87, 2, 87, 13, 91, 1, 91, 5, 95,
+	2, 9, 95, 99, 1, 99, 5, 103, 1, 2, 103, 107, 1, 10, 107, 0,
+	99, 2, 14, 0, 0,
+}
+
+var day_02_addresses []int = []int{
+	0, 4, 8, 12, 16, 20, 24, 28, 32, 36, 40, 44, 48, 52, 56, 60,
+	64, 68, 72, 76, 80, 84, 88, 92, 96, 100, 104, 108, 112,
+}
+
+func run_day_02(native *intcode.Native, ip int) int {
+	for {
+		if native.Stale(ip) {
+			return ip
+		}
+
+		switch ip {
+		case 0:
+			// ADD  [0], [0], [3]
+			var value_0 int = native.Read(0)
+			var value_1 int = native.Read(0)
+			native.Count++
+			if native.Write(3, value_0+value_1) {
+				return 4
+			}
+			ip = 4
+		case 4:
+			// ADD  [1], [2], [3]
+			var value_0 int = native.Read(1)
+			var value_1 int = native.Read(2)
+			native.Count++
+			if native.Write(3, value_0+value_1) {
+				return 8
+			}
+			ip = 8
+		case 8:
+			// ADD  [3], [4], [3]
+			var value_0 int = native.Read(3)
+			var value_1 int = native.Read(4)
+			native.Count++
+			if native.Write(3, value_0+value_1) {
+				return 12
+			}
+			ip = 12
+		case 12:
+			// ADD  [5], [0], [3]
+			var value_0 int = native.Read(5)
+			var value_1 int = native.Read(0)
+			native.Count++
+			if native.Write(3, value_0+value_1) {
+				return 16
+			}
+			ip = 16
+		case 16:
+			// MUL  [1], [9], [19]
+			var value_0 int = native.Read(1)
+			var value_1 int = native.Read(9)
+			native.Count++
+			if native.Write(19, value_0*value_1) {
+				return 20
+			}
+			ip = 20
+		case 20:
+			// ADD  [19], [5], [23]
+			var value_0 int = native.Read(19)
+			var value_1 int = native.Read(5)
+			native.Count++
+			if native.Write(23, value_0+value_1) {
+				return 24
+			}
+			ip = 24
+		case 24:
+			// MUL  [23], [13], [27]
+			var value_0 int = native.Read(23)
+			var value_1 int = native.Read(13)
+			native.Count++
+			if native.Write(27, value_0*value_1) {
+				return 28
+			}
+			ip = 28
+		case 28:
+			// ADD  [10], [27], [31]
+			var value_0 int = native.Read(10)
+			var value_1 int = native.Read(27)
+			native.Count++
+			if native.Write(31, value_0+value_1) {
+				return 32
+			}
+			ip = 32
+		case 32:
+			// MUL  [31], [6], [35]
+			var value_0 int = native.Read(31)
+			var value_1 int = native.Read(6)
+			native.Count++
+			if native.Write(35, value_0*value_1) {
+				return 36
+			}
+			ip = 36
+		case 36:
+			// ADD  [5], [35], [39]
+			var value_0 int = native.Read(5)
+			var value_1 int = native.Read(35)
+			native.Count++
+			if native.Write(39, value_0+value_1) {
+				return 40
+			}
+			ip = 40
+		case 40:
+			// ADD  [39], [10], [43]
+			var value_0 int = native.Read(39)
+			var value_1 int = native.Read(10)
+			native.Count++
+			if native.Write(43, value_0+value_1) {
+				return 44
+			}
+			ip = 44
+		case 44:
+			// MUL  [9], [43], [47]
+			var value_0 int = native.Read(9)
+			var value_1 int = native.Read(43)
+			native.Count++
+			if native.Write(47, value_0*value_1) {
+				return 48
+			}
+			ip = 48
+		case 48:
+			// ADD  [47], [5], [51]
+			var value_0 int = native.Read(47)
+			var value_1 int = native.Read(5)
+			native.Count++
+			if native.Write(51, value_0+value_1) {
+				return 52
+			}
+			ip = 52
+		case 52:
+			// MUL  [51], [9], [55]
+			var value_0 int = native.Read(51)
+			var value_1 int = native.Read(9)
+			native.Count++
+			if native.Write(55, value_0*value_1) {
+				return 56
+			}
+			ip = 56
+		case 56:
+			// ADD  [13], [55], [59]
+			var value_0 int = native.Read(13)
+			var value_1 int = native.Read(55)
+			native.Count++
+			if native.Write(59, value_0+value_1) {
+				return 60
+			}
+			ip = 60
+		case 60:
+			// ADD  [13], [59], [63]
+			var value_0 int = native.Read(13)
+			var value_1 int = native.Read(59)
+			native.Count++
+			if native.Write(63, value_0+value_1) {
+				return 64
+			}
+			ip = 64
+		case 64:
+			// ADD  [6], [63], [67]
+			var value_0 int = native.Read(6)
+			var value_1 int = native.Read(63)
+			native.Count++
+			if native.Write(67, value_0+value_1) {
+				return 68
+			}
+			ip = 68
+		case 68:
+			// MUL  [13], [67], [71]
+			var value_0 int = native.Read(13)
+			var value_1 int = native.Read(67)
+			native.Count++
+			if native.Write(71, value_0*value_1) {
+				return 72
+			}
+			ip = 72
+		case 72:
+			// ADD  [10], [71], [75]
+			var value_0 int = native.Read(10)
+			var value_1 int = native.Read(71)
+			native.Count++
+			if native.Write(75, value_0+value_1) {
+				return 76
+			}
+			ip = 76
+		case 76:
+			// MUL  [13], [75], [79]
+			var value_0 int = native.Read(13)
+			var value_1 int = native.Read(75)
+			native.Count++
+			if native.Write(79, value_0*value_1) {
+				return 80
+			}
+			ip = 80
+		case 80:
+			// ADD  [5], [79], [83]
+			var value_0 int = native.Read(5)
+			var value_1 int = native.Read(79)
+			native.Count++
+			if native.Write(83, value_0+value_1) {
+				return 84
+			}
+			ip = 84
+		case 84:
+			// MUL  [83], [9], [87]
+			var value_0 int = native.Read(83)
+			var value_1 int = native.Read(9)
+			native.Count++
+			if native.Write(87, value_0*value_1) {
+				return 88
+			}
+			ip = 88
+		case 88:
+			// MUL  [87], [13], [91]
+			var value_0 int = native.Read(87)
+			var value_1 int = native.Read(13)
+			native.Count++
+			if native.Write(91, value_0*value_1) {
+				return 92
+			}
+			ip = 92
+		case 92:
+			// ADD  [91], [5], [95]
+			var value_0 int = native.Read(91)
+			var value_1 int = native.Read(5)
+			native.Count++
+			if native.Write(95, value_0+value_1) {
+				return 96
+			}
+			ip = 96
+		case 96:
+			// MUL  [9], [95], [99]
+			var value_0 int = native.Read(9)
+			var value_1 int = native.Read(95)
+			native.Count++
+			if native.Write(99, value_0*value_1) {
+				return 100
+			}
+			ip = 100
+		case 100:
+			// ADD  [99], [5], [103]
+			var value_0 int = native.Read(99)
+			var value_1 int = native.Read(5)
+			native.Count++
+			if native.Write(103, value_0+value_1) {
+				return 104
+			}
+			ip = 104
+		case 104:
+			// ADD  [2], [103], [107]
+			var value_0 int = native.Read(2)
+			var value_1 int = native.Read(103)
+			native.Count++
+			if native.Write(107, value_0+value_1) {
+				return 108
+			}
+			ip = 108
+		case 108:
+			// ADD  [10], [107], [0]
+			var value_0 int = native.Read(10)
+			var value_1 int = native.Read(107)
+			native.Count++
+			if native.Write(0, value_0+value_1) {
+				return 112
+			}
+			ip = 112
+		case 112:
+			// HLT
+			return 112
+		default:
+			return ip
+		}
+	}
+}
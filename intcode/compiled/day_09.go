@@ -0,0 +1,1118 @@
+// Code generated by intcode.Compile. DO NOT EDIT.
+
+package compiled
+
+import "github.com/Sousa99/AdventOfCode2019/intcode"
+
+var day_09 *intcode.CompiledProgram = intcode.NewCompiledProgram(day_09_image, day_09_addresses, run_day_09)
+
+var day_09_image []int = []int{
+	1102, 34463338, 34463338, 63, 1007, 63, 34463338, 63, 1005, 63, 53, 1102, 1, 3, 1000, 109,
+	988, 209, 12, 9, 1000, 209, 6, 209, 3, 203, 0, 1008, 1000, 1, 63, 1005,
+	63, 65, 1008, 1000, 2, 63, 1005, 63, 904, 1008, 1000, 0, 63, 1005, 63, 58,
+	4, 25, 104, 0, 99, 4, 0, 104, 0, 99, 4, 17, 104, 0, 99, 0,
+	0, 1101, 0, 493, 1024, 1102, 1, 38, 1015, 1101, 20, 0, 1011, 1101, 0, 509,
+	1026, 1101, 0, 32, 1018, 1101, 0, 333, 1022, 1102, 1, 0, 1020, 1101, 326, 0,
+	1023, 1101, 0, 33, 1010, 1101, 21, 0, 1016, 1101, 25, 0, 1004, 1102, 28, 1,
+	1008, 1102, 1, 506, 1027, 1102, 488, 1, 1025, 1101, 0, 27, 1013, 1101, 1, 0,
+	1021, 1101, 0, 34, 1019, 1101, 607, 0, 1028, 1102, 1, 23, 1003, 1102, 26, 1,
+	1007, 1102, 29, 1, 1009, 1101, 31, 0, 1000, 1102, 37, 1, 1012, 1101, 30, 0,
+	1005, 1101, 602, 0, 1029, 1101, 36, 0, 1002, 1102, 1, 22, 1001, 1102, 1, 35,
+	1014, 1102, 24, 1, 1006, 1102, 39, 1, 1017, 109, 4, 21102, 40, 1, 6, 1008,
+	1010, 40, 63, 1005, 63, 203, 4, 187, 1106, 0, 207, 1001, 64, 1, 64, 1002,
+	64, 2, 64, 109, 13, 1206, 3, 221, 4, 213, 1106, 0, 225, 1001, 64, 1,
+	64, 1002, 64, 2, 64, 109, -5, 1208, -9, 22, 63, 1005, 63, 241, 1106, 0,
+	247, 4, 231, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -5, 21107, 41, 40,
+	3, 1005, 1010, 263, 1106, 0, 269, 4, 253, 1001, 64, 1, 64, 1002, 64, 2,
+	64, 109, -1, 1202, 3, 1, 63, 1008, 63, 29, 63, 1005, 63, 295, 4, 275,
+	1001, 64, 1, 64, 1106, 0, 295, 1002, 64, 2, 64, 109, 16, 21108, 42, 42,
+	-8, 1005, 1014, 313, 4, 301, 1105, 1, 317, 1001, 64, 1, 64, 1002, 64, 2,
+	64, 109, -4, 2105, 1, 5, 1001, 64, 1, 64, 1105, 1, 335, 4, 323, 1002,
+	64, 2, 64, 109, -5, 1207, -4, 28, 63, 1005, 63, 355, 1001, 64, 1, 64,
+	1105, 1, 357, 4, 341, 1002, 64, 2, 64, 109, 2, 21102, 43, 1, -1, 1008,
+	1014, 45, 63, 1005, 63, 377, 1106, 0, 383, 4, 363, 1001, 64, 1, 64, 1002,
+	64, 2, 64, 109, -10, 1208, -3, 36, 63, 1005, 63, 401, 4, 389, 1106, 0,
+	405, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, 6, 21107, 44, 45, 1, 1005,
+	1012, 423, 4, 411, 1105, 1, 427, 1001, 64, 1, 64, 1002, 64, 2, 64, 109,
+	4, 21101, 45, 0, 3, 1008, 1018, 45, 63, 1005, 63, 453, 4, 433, 1001, 64,
+	1, 64, 1105, 1, 453, 1002, 64, 2, 64, 109, -23, 2101, 0, 10, 63, 1008,
+	63, 36, 63, 1005, 63, 475, 4, 459, 1106, 0, 479, 1001, 64, 1, 64, 1002,
+	64, 2, 64, 109, 26, 2105, 1, 6, 4, 485, 1105, 1, 497, 1001, 64, 1,
+	64, 1002, 64, 2, 64, 109, 4, 2106, 0, 5, 1105, 1, 515, 4, 503, 1001,
+	64, 1, 64, 1002, 64, 2, 64, 109, -25, 1201, 10, 0, 63, 1008, 63, 26,
+	63, 1005, 63, 537, 4, 521, 1105, 1, 541, 1001, 64, 1, 64, 1002, 64, 2,
+	64, 109, 18, 21101, 46, 0, -1, 1008, 1014, 43, 63, 1005, 63, 565, 1001, 64,
+	1, 64, 1106, 0, 567, 4, 547, 1002, 64, 2, 64, 109, -6, 1201, -4, 0,
+	63, 1008, 63, 33, 63, 1005, 63, 587, 1105, 1, 593, 4, 573, 1001, 64, 1,
+	64, 1002, 64, 2, 64, 109, 22, 2106, 0, -3, 4, 599, 1105, 1, 611, 1001,
+	64, 1, 64, 1002, 64, 2, 64, 109, -28, 2102, 1, -2, 63, 1008, 63, 22,
+	63, 1005, 63, 633, 4, 617, 1105, 1, 637, 1001, 64, 1, 64, 1002, 64, 2,
+	64, 109, -1, 21108, 47, 44, 9, 1005, 1011, 653, 1105, 1, 659, 4, 643, 1001,
+	64, 1, 64, 1002, 64, 2, 64, 109, 10, 2107, 24, -8, 63, 1005, 63, 681,
+	4, 665, 1001, 64, 1, 64, 1105, 1, 681, 1002, 64, 2, 64, 109, -11, 2107,
+	31, 4, 63, 1005, 63, 697, 1106, 0, 703, 4, 687, 1001, 64, 1, 64, 1002,
+	64, 2, 64, 109, 8, 2101, 0, -8, 63, 1008, 63, 23, 63, 1005, 63, 727,
+	1001, 64, 1, 64, 1105, 1, 729, 4, 709, 1002, 64, 2, 64, 109, -16, 2108,
+	21, 10, 63, 1005, 63, 749, 1001, 64, 1, 64, 1106, 0, 751, 4, 735, 1002,
+	64, 2, 64, 109, 17, 2108, 36, -8, 63, 1005, 63, 769, 4, 757, 1105, 1,
+	773, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, -10, 1207, 1, 23, 63, 1005,
+	63, 791, 4, 779, 1105, 1, 795, 1001, 64, 1, 64, 1002, 64, 2, 64, 109,
+	-3, 2102, 1, 6, 63, 1008, 63, 22, 63, 1005, 63, 815, 1106, 0, 821, 4,
+	801, 1001, 64, 1, 64, 1002, 64, 2, 64, 109, 16, 1205, 7, 837, 1001, 64,
+	1, 64, 1105, 1, 839, 4, 827, 1002, 64, 2, 64, 109, -5, 1202, 0, 1,
+	63, 1008, 63, 30, 63, 1005, 63, 863, 1001, 64, 1, 64, 1106, 0, 865, 4,
+	845, 1002, 64, 2, 64, 109, 4, 1205, 9, 883, 4, 871, 1001, 64, 1, 64,
+	1106, 0, 883, 1002, 64, 2, 64, 109, 16, 1206, -7, 899, 1001, 64, 1, 64,
+	1106, 0, 901, 4, 889, 4, 64, 99, 21102, 1, 27, 1, 21101, 915, 0, 0,
+	1105, 1, 922, 21201, 1, 47633, 1, 204, 1, 99, 109, 3, 1207, -2, 3, 63,
+	1005, 63, 964, 21201, -2, -1, 1, 21102, 942, 1, 0, 1105, 1, 922, 22102, 1,
+	1, -1, 21201, -2, -3, 1, 21101, 957, 0, 0, 1106, 0, 922, 22201, 1, -1,
+	-2, 1105, 1, 968, 22101, 0, -2, -2, 109, -3, 2106, 0, 0,
+}
+
+var day_09_addresses []int = []int{
+	0, 4, 8, 11, 15, 17, 19, 21, 23, 25, 27, 31, 34, 38, 41, 45,
+	48, 50, 52, 53, 55, 57, 58, 60, 62, 65, 69, 73, 77, 81, 85, 89,
+	93, 97, 101, 105, 109, 113, 117, 121, 125, 129, 133, 137, 141, 145, 149, 153,
+	157, 161, 165, 169, 173, 177, 181, 185, 187, 191, 195, 198, 200, 203, 207, 211,
+	213, 216, 218, 221, 225, 229, 231, 235, 238, 241, 243, 247, 251, 253, 257, 260,
+	263, 265, 269, 273, 275, 279, 283, 286, 288, 292, 295, 299, 301, 305, 308, 310,
+	313, 317, 321, 323, 904, 908, 912, 915, 919, 921, 922, 924, 928, 931, 935, 939,
+	942, 946, 950, 954, 957, 961, 964, 968, 970,
+}
+
+func run_day_09(native *intcode.Native, ip int) int {
+	for {
+		if native.Stale(ip) {
+			return ip
+		}
+
+		switch ip {
+		case 0:
+			// MUL  #34463338, #34463338, [63]
+			native.Count++
+			if native.Write(63, (1187721666102244)) {
+				return 4
+			}
+			ip = 4
+		case 4:
+			// LT   [63], #34463338, [63]
+			var value_0 int = native.Read(63)
+			native.Count++
+			var result int = 0
+			if value_0 < (34463338) {
+				result = 1
+			}
+			if native.Write(63, result) {
+				return 8
+			}
+			ip = 8
+		case 8:
+			// JNZ  [63], #53
+			var value_0 int = native.Read(63)
+			native.Count++
+			if value_0 != 0 {
+				ip = (53)
+			} else {
+				ip = 11
+			}
+		case 11:
+			// MUL  #1, #3, [1000]
+			native.Count++
+			if native.Write(1000, (3)) {
+				return 15
+			}
+			ip = 15
+		case 15:
+			// ARB  #988
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (988)
+			ip = 17
+		case 17:
+			// ARB  rb+12
+			var address_0 int = native.RelativeBase + (12)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 17
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			native.RelativeBase = native.RelativeBase + value_0
+			ip = 19
+		case 19:
+			// ARB  [1000]
+			var value_0 int = native.Read(1000)
+			native.Count++
+			native.RelativeBase = native.RelativeBase + value_0
+			ip = 21
+		case 21:
+			// ARB  rb+6
+			var address_0 int = native.RelativeBase + (6)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 21
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			native.RelativeBase = native.RelativeBase + value_0
+			ip = 23
+		case 23:
+			// ARB  rb+3
+			var address_0 int = native.RelativeBase + (3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 23
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			native.RelativeBase = native.RelativeBase + value_0
+			ip = 25
+		case 25:
+			// IN   rb+0
+			var address_0 int = native.RelativeBase + (0)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 25
+			}
+			input, available := native.Input()
+			if !available {
+				return 25
+			}
+			native.Count++
+			if native.Write(address_0, input) {
+				return 27
+			}
+			ip = 27
+		case 27:
+			// EQ   [1000], #1, [63]
+			var value_0 int = native.Read(1000)
+			native.Count++
+			var result int = 0
+			if value_0 == (1) {
+				result = 1
+			}
+			if native.Write(63, result) {
+				return 31
+			}
+			ip = 31
+		case 31:
+			// JNZ  [63], #65
+			var value_0 int = native.Read(63)
+			native.Count++
+			if value_0 != 0 {
+				ip = (65)
+			} else {
+				ip = 34
+			}
+		case 34:
+			// EQ   [1000], #2, [63]
+			var value_0 int = native.Read(1000)
+			native.Count++
+			var result int = 0
+			if value_0 == (2) {
+				result = 1
+			}
+			if native.Write(63, result) {
+				return 38
+			}
+			ip = 38
+		case 38:
+			// JNZ  [63], #904
+			var value_0 int = native.Read(63)
+			native.Count++
+			if value_0 != 0 {
+				ip = (904)
+			} else {
+				ip = 41
+			}
+		case 41:
+			// EQ   [1000], #0, [63]
+			var value_0 int = native.Read(1000)
+			native.Count++
+			var result int = 0
+			if value_0 == (0) {
+				result = 1
+			}
+			if native.Write(63, result) {
+				return 45
+			}
+			ip = 45
+		case 45:
+			// JNZ  [63], #58
+			var value_0 int = native.Read(63)
+			native.Count++
+			if value_0 != 0 {
+				ip = (58)
+			} else {
+				ip = 48
+			}
+		case 48:
+			// OUT  [25]
+			var value_0 int = native.Read(25)
+			native.Count++
+			native.Output(value_0)
+			ip = 50
+		case 50:
+			// OUT  #0
+			native.Count++
+			native.Output((0))
+			ip = 52
+		case 52:
+			// HLT
+			return 52
+		case 53:
+			// OUT  [0]
+			var value_0 int = native.Read(0)
+			native.Count++
+			native.Output(value_0)
+			ip = 55
+		case 55:
+			// OUT  #0
+			native.Count++
+			native.Output((0))
+			ip = 57
+		case 57:
+			// HLT
+			return 57
+		case 58:
+			// OUT  [17]
+			var value_0 int = native.Read(17)
+			native.Count++
+			native.Output(value_0)
+			ip = 60
+		case 60:
+			// OUT  #0
+			native.Count++
+			native.Output((0))
+			ip = 62
+		case 62:
+			// HLT
+			return 62
+		case 65:
+			// ADD  #0, #493, [1024]
+			native.Count++
+			if native.Write(1024, (493)) {
+				return 69
+			}
+			ip = 69
+		case 69:
+			// MUL  #1, #38, [1015]
+			native.Count++
+			if native.Write(1015, (38)) {
+				return 73
+			}
+			ip = 73
+		case 73:
+			// ADD  #20, #0, [1011]
+			native.Count++
+			if native.Write(1011, (20)) {
+				return 77
+			}
+			ip = 77
+		case 77:
+			// ADD  #0, #509, [1026]
+			native.Count++
+			if native.Write(1026, (509)) {
+				return 81
+			}
+			ip = 81
+		case 81:
+			// ADD  #0, #32, [1018]
+			native.Count++
+			if native.Write(1018, (32)) {
+				return 85
+			}
+			ip = 85
+		case 85:
+			// ADD  #0, #333, [1022]
+			native.Count++
+			if native.Write(1022, (333)) {
+				return 89
+			}
+			ip = 89
+		case 89:
+			// MUL  #1, #0, [1020]
+			native.Count++
+			if native.Write(1020, (0)) {
+				return 93
+			}
+			ip = 93
+		case 93:
+			// ADD  #326, #0, [1023]
+			native.Count++
+			if native.Write(1023, (326)) {
+				return 97
+			}
+			ip = 97
+		case 97:
+			// ADD  #0, #33, [1010]
+			native.Count++
+			if native.Write(1010, (33)) {
+				return 101
+			}
+			ip = 101
+		case 101:
+			// ADD  #21, #0, [1016]
+			native.Count++
+			if native.Write(1016, (21)) {
+				return 105
+			}
+			ip = 105
+		case 105:
+			// ADD  #25, #0, [1004]
+			native.Count++
+			if native.Write(1004, (25)) {
+				return 109
+			}
+			ip = 109
+		case 109:
+			// MUL  #28, #1, [1008]
+			native.Count++
+			if native.Write(1008, (28)) {
+				return 113
+			}
+			ip = 113
+		case 113:
+			// MUL  #1, #506, [1027]
+			native.Count++
+			if native.Write(1027, (506)) {
+				return 117
+			}
+			ip = 117
+		case 117:
+			// MUL  #488, #1, [1025]
+			native.Count++
+			if native.Write(1025, (488)) {
+				return 121
+			}
+			ip = 121
+		case 121:
+			// ADD  #0, #27, [1013]
+			native.Count++
+			if native.Write(1013, (27)) {
+				return 125
+			}
+			ip = 125
+		case 125:
+			// ADD  #1, #0, [1021]
+			native.Count++
+			if native.Write(1021, (1)) {
+				return 129
+			}
+			ip = 129
+		case 129:
+			// ADD  #0, #34, [1019]
+			native.Count++
+			if native.Write(1019, (34)) {
+				return 133
+			}
+			ip = 133
+		case 133:
+			// ADD  #607, #0, [1028]
+			native.Count++
+			if native.Write(1028, (607)) {
+				return 137
+			}
+			ip = 137
+		case 137:
+			// MUL  #1, #23, [1003]
+			native.Count++
+			if native.Write(1003, (23)) {
+				return 141
+			}
+			ip = 141
+		case 141:
+			// MUL  #26, #1, [1007]
+			native.Count++
+			if native.Write(1007, (26)) {
+				return 145
+			}
+			ip = 145
+		case 145:
+			// MUL  #29, #1, [1009]
+			native.Count++
+			if native.Write(1009, (29)) {
+				return 149
+			}
+			ip = 149
+		case 149:
+			// ADD  #31, #0, [1000]
+			native.Count++
+			if native.Write(1000, (31)) {
+				return 153
+			}
+			ip = 153
+		case 153:
+			// MUL  #37, #1, [1012]
+			native.Count++
+			if native.Write(1012, (37)) {
+				return 157
+			}
+			ip = 157
+		case 157:
+			// ADD  #30, #0, [1005]
+			native.Count++
+			if native.Write(1005, (30)) {
+				return 161
+			}
+			ip = 161
+		case 161:
+			// ADD  #602, #0, [1029]
+			native.Count++
+			if native.Write(1029, (602)) {
+				return 165
+			}
+			ip = 165
+		case 165:
+			// ADD  #36, #0, [1002]
+			native.Count++
+			if native.Write(1002, (36)) {
+				return 169
+			}
+			ip = 169
+		case 169:
+			// MUL  #1, #22, [1001]
+			native.Count++
+			if native.Write(1001, (22)) {
+				return 173
+			}
+			ip = 173
+		case 173:
+			// MUL  #1, #35, [1014]
+			native.Count++
+			if native.Write(1014, (35)) {
+				return 177
+			}
+			ip = 177
+		case 177:
+			// MUL  #24, #1, [1006]
+			native.Count++
+			if native.Write(1006, (24)) {
+				return 181
+			}
+			ip = 181
+		case 181:
+			// MUL  #39, #1, [1017]
+			native.Count++
+			if native.Write(1017, (39)) {
+				return 185
+			}
+			ip = 185
+		case 185:
+			// ARB  #4
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (4)
+			ip = 187
+		case 187:
+			// MUL  #40, #1, rb+6
+			var address_2 int = native.RelativeBase + (6)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 187
+			}
+			native.Count++
+			if native.Write(address_2, (40)) {
+				return 191
+			}
+			ip = 191
+		case 191:
+			// EQ   [1010], #40, [63]
+			var value_0 int = native.Read(1010)
+			native.Count++
+			var result int = 0
+			if value_0 == (40) {
+				result = 1
+			}
+			if native.Write(63, result) {
+				return 195
+			}
+			ip = 195
+		case 195:
+			// JNZ  [63], #203
+			var value_0 int = native.Read(63)
+			native.Count++
+			if value_0 != 0 {
+				ip = (203)
+			} else {
+				ip = 198
+			}
+		case 198:
+			// OUT  [187]
+			var value_0 int = native.Read(187)
+			native.Count++
+			native.Output(value_0)
+			ip = 200
+		case 200:
+			// JZ   #0, #207
+			native.Count++
+			if (0) == 0 {
+				ip = (207)
+			} else {
+				ip = 203
+			}
+		case 203:
+			// ADD  [64], #1, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0+(1)) {
+				return 207
+			}
+			ip = 207
+		case 207:
+			// MUL  [64], #2, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0*(2)) {
+				return 211
+			}
+			ip = 211
+		case 211:
+			// ARB  #13
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (13)
+			ip = 213
+		case 213:
+			// JZ   rb+3, #221
+			var address_0 int = native.RelativeBase + (3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 213
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			if value_0 == 0 {
+				ip = (221)
+			} else {
+				ip = 216
+			}
+		case 216:
+			// OUT  [213]
+			var value_0 int = native.Read(213)
+			native.Count++
+			native.Output(value_0)
+			ip = 218
+		case 218:
+			// JZ   #0, #225
+			native.Count++
+			if (0) == 0 {
+				ip = (225)
+			} else {
+				ip = 221
+			}
+		case 221:
+			// ADD  [64], #1, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0+(1)) {
+				return 225
+			}
+			ip = 225
+		case 225:
+			// MUL  [64], #2, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0*(2)) {
+				return 229
+			}
+			ip = 229
+		case 229:
+			// ARB  #-5
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (-5)
+			ip = 231
+		case 231:
+			// EQ   rb-9, #22, [63]
+			var address_0 int = native.RelativeBase + (-9)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 231
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			var result int = 0
+			if value_0 == (22) {
+				result = 1
+			}
+			if native.Write(63, result) {
+				return 235
+			}
+			ip = 235
+		case 235:
+			// JNZ  [63], #241
+			var value_0 int = native.Read(63)
+			native.Count++
+			if value_0 != 0 {
+				ip = (241)
+			} else {
+				ip = 238
+			}
+		case 238:
+			// JZ   #0, #247
+			native.Count++
+			if (0) == 0 {
+				ip = (247)
+			} else {
+				ip = 241
+			}
+		case 241:
+			// OUT  [231]
+			var value_0 int = native.Read(231)
+			native.Count++
+			native.Output(value_0)
+			ip = 243
+		case 243:
+			// ADD  [64], #1, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0+(1)) {
+				return 247
+			}
+			ip = 247
+		case 247:
+			// MUL  [64], #2, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0*(2)) {
+				return 251
+			}
+			ip = 251
+		case 251:
+			// ARB  #-5
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (-5)
+			ip = 253
+		case 253:
+			// LT   #41, #40, rb+3
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 253
+			}
+			native.Count++
+			var result int = 0
+			if (41) < (40) {
+				result = 1
+			}
+			if native.Write(address_2, result) {
+				return 257
+			}
+			ip = 257
+		case 257:
+			// JNZ  [1010], #263
+			var value_0 int = native.Read(1010)
+			native.Count++
+			if value_0 != 0 {
+				ip = (263)
+			} else {
+				ip = 260
+			}
+		case 260:
+			// JZ   #0, #269
+			native.Count++
+			if (0) == 0 {
+				ip = (269)
+			} else {
+				ip = 263
+			}
+		case 263:
+			// OUT  [253]
+			var value_0 int = native.Read(253)
+			native.Count++
+			native.Output(value_0)
+			ip = 265
+		case 265:
+			// ADD  [64], #1, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0+(1)) {
+				return 269
+			}
+			ip = 269
+		case 269:
+			// MUL  [64], #2, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0*(2)) {
+				return 273
+			}
+			ip = 273
+		case 273:
+			// ARB  #-1
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (-1)
+			ip = 275
+		case 275:
+			// MUL  rb+3, #1, [63]
+			var address_0 int = native.RelativeBase + (3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 275
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			if native.Write(63, value_0*(1)) {
+				return 279
+			}
+			ip = 279
+		case 279:
+			// EQ   [63], #29, [63]
+			var value_0 int = native.Read(63)
+			native.Count++
+			var result int = 0
+			if value_0 == (29) {
+				result = 1
+			}
+			if native.Write(63, result) {
+				return 283
+			}
+			ip = 283
+		case 283:
+			// JNZ  [63], #295
+			var value_0 int = native.Read(63)
+			native.Count++
+			if value_0 != 0 {
+				ip = (295)
+			} else {
+				ip = 286
+			}
+		case 286:
+			// OUT  [275]
+			var value_0 int = native.Read(275)
+			native.Count++
+			native.Output(value_0)
+			ip = 288
+		case 288:
+			// ADD  [64], #1, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0+(1)) {
+				return 292
+			}
+			ip = 292
+		case 292:
+			// JZ   #0, #295
+			native.Count++
+			if (0) == 0 {
+				ip = (295)
+			} else {
+				ip = 295
+			}
+		case 295:
+			// MUL  [64], #2, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0*(2)) {
+				return 299
+			}
+			ip = 299
+		case 299:
+			// ARB  #16
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (16)
+			ip = 301
+		case 301:
+			// EQ   #42, #42, rb-8
+			var address_2 int = native.RelativeBase + (-8)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 301
+			}
+			native.Count++
+			var result int = 0
+			if (42) == (42) {
+				result = 1
+			}
+			if native.Write(address_2, result) {
+				return 305
+			}
+			ip = 305
+		case 305:
+			// JNZ  [1014], #313
+			var value_0 int = native.Read(1014)
+			native.Count++
+			if value_0 != 0 {
+				ip = (313)
+			} else {
+				ip = 308
+			}
+		case 308:
+			// OUT  [301]
+			var value_0 int = native.Read(301)
+			native.Count++
+			native.Output(value_0)
+			ip = 310
+		case 310:
+			// JNZ  #1, #317
+			native.Count++
+			if (1) != 0 {
+				ip = (317)
+			} else {
+				ip = 313
+			}
+		case 313:
+			// ADD  [64], #1, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0+(1)) {
+				return 317
+			}
+			ip = 317
+		case 317:
+			// MUL  [64], #2, [64]
+			var value_0 int = native.Read(64)
+			native.Count++
+			if native.Write(64, value_0*(2)) {
+				return 321
+			}
+			ip = 321
+		case 321:
+			// ARB  #-4
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (-4)
+			ip = 323
+		case 323:
+			// JNZ  #1, rb+5
+			var address_1 int = native.RelativeBase + (5)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 323
+			}
+			var value_1 int = native.Read(address_1)
+			native.Count++
+			if (1) != 0 {
+				ip = value_1
+			} else {
+				ip = 326
+			}
+		case 904:
+			// MUL  #1, #27, rb+1
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 904
+			}
+			native.Count++
+			if native.Write(address_2, (27)) {
+				return 908
+			}
+			ip = 908
+		case 908:
+			// ADD  #915, #0, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 908
+			}
+			native.Count++
+			if native.Write(address_2, (915)) {
+				return 912
+			}
+			ip = 912
+		case 912:
+			// JNZ  #1, #922
+			native.Count++
+			if (1) != 0 {
+				ip = (922)
+			} else {
+				ip = 915
+			}
+		case 915:
+			// ADD  rb+1, #47633, rb+1
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 915
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 915
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(47633)) {
+				return 919
+			}
+			ip = 919
+		case 919:
+			// OUT  rb+1
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 919
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			native.Output(value_0)
+			ip = 921
+		case 921:
+			// HLT
+			return 921
+		case 922:
+			// ARB  #3
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (3)
+			ip = 924
+		case 924:
+			// LT   rb-2, #3, [63]
+			var address_0 int = native.RelativeBase + (-2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 924
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			var result int = 0
+			if value_0 < (3) {
+				result = 1
+			}
+			if native.Write(63, result) {
+				return 928
+			}
+			ip = 928
+		case 928:
+			// JNZ  [63], #964
+			var value_0 int = native.Read(63)
+			native.Count++
+			if value_0 != 0 {
+				ip = (964)
+			} else {
+				ip = 931
+			}
+		case 931:
+			// ADD  rb-2, #-1, rb+1
+			var address_0 int = native.RelativeBase + (-2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 931
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 931
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(-1)) {
+				return 935
+			}
+			ip = 935
+		case 935:
+			// MUL  #942, #1, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 935
+			}
+			native.Count++
+			if native.Write(address_2, (942)) {
+				return 939
+			}
+			ip = 939
+		case 939:
+			// JNZ  #1, #922
+			native.Count++
+			if (1) != 0 {
+				ip = (922)
+			} else {
+				ip = 942
+			}
+		case 942:
+			// MUL  #1, rb+1, rb-1
+			var address_1 int = native.RelativeBase + (1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 942
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 942
+			}
+			native.Count++
+			if native.Write(address_2, (1)*value_1) {
+				return 946
+			}
+			ip = 946
+		case 946:
+			// ADD  rb-2, #-3, rb+1
+			var address_0 int = native.RelativeBase + (-2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 946
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 946
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(-3)) {
+				return 950
+			}
+			ip = 950
+		case 950:
+			// ADD  #957, #0, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 950
+			}
+			native.Count++
+			if native.Write(address_2, (957)) {
+				return 954
+			}
+			ip = 954
+		case 954:
+			// JZ   #0, #922
+			native.Count++
+			if (0) == 0 {
+				ip = (922)
+			} else {
+				ip = 957
+			}
+		case 957:
+			// ADD  rb+1, rb-1, rb-2
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 957
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 957
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 957
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 961
+			}
+			ip = 961
+		case 961:
+			// JNZ  #1, #968
+			native.Count++
+			if (1) != 0 {
+				ip = (968)
+			} else {
+				ip = 964
+			}
+		case 964:
+			// ADD  #0, rb-2, rb-2
+			var address_1 int = native.RelativeBase + (-2)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 964
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 964
+			}
+			native.Count++
+			if native.Write(address_2, (0)+value_1) {
+				return 968
+			}
+			ip = 968
+		case 968:
+			// ARB  #-3
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (-3)
+			ip = 970
+		case 970:
+			// JZ   #0, rb+0
+			var address_1 int = native.RelativeBase + (0)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 970
+			}
+			var value_1 int = native.Read(address_1)
+			native.Count++
+			if (0) == 0 {
+				ip = value_1
+			} else {
+				ip = 973
+			}
+		default:
+			return ip
+		}
+	}
+}
@@ -0,0 +1,721 @@
+// Code generated by intcode.Compile. DO NOT EDIT.
+
+package compiled
+
+import "github.com/Sousa99/AdventOfCode2019/intcode"
+
+var day_15 *intcode.CompiledProgram = intcode.NewCompiledProgram(day_15_image, day_15_addresses, run_day_15)
+
+var day_15_image []int = []int{
+	3, 1033, 1008, 1033, 1, 1032, 1005, 1032, 31, 1008, 1033, 2, 1032, 1005, 1032, 58,
+	1008, 1033, 3, 1032, 1005, 1032, 81, 1008, 1033, 4, 1032, 1005, 1032, 104, 99, 1002,
+	1034, 1, 1039, 1002, 1036, 1, 1041, 1001, 1035, -1, 1040, 1008, 1038, 0, 1043, 102,
+	-1, 1043, 1032, 1, 1037, 1032, 1042, 1106, 0, 124, 1001, 1034, 0, 1039, 1002, 1036,
+	1, 1041, 1001, 1035, 1, 1040, 1008, 1038, 0, 1043, 1, 1037, 1038, 1042, 1106, 0,
+	124, 1001, 1034, -1, 1039, 1008, 1036, 0, 1041, 1001, 1035, 0, 1040, 102, 1, 1038,
+	1043, 1002, 1037, 1, 1042, 1106, 0, 124, 1001, 1034, 1, 1039, 1008, 1036, 0, 1041,
+	102, 1, 1035, 1040, 1001, 1038, 0, 1043, 1002, 1037, 1, 1042, 1006, 1039, 217, 1006,
+	1040, 217, 1008, 1039, 40, 1032, 1005, 1032, 217, 1008, 1040, 40, 1032, 1005, 1032, 217,
+	1008, 1039, 5, 1032, 1006, 1032, 165, 1008, 1040, 35, 1032, 1006, 1032, 165, 1102, 1,
+	2, 1044, 1106, 0, 224, 2, 1041, 1043, 1032, 1006, 1032, 179, 1102, 1, 1, 1044,
+	1106, 0, 224, 1, 1041, 1043, 1032, 1006, 1032, 217, 1, 1042, 1043, 1032, 1001, 1032,
+	-1, 1032, 1002, 1032, 39, 1032, 1, 1032, 1039, 1032, 101, -1, 1032, 1032, 101, 252,
+	1032, 211, 1007, 0, 38, 1044, 1106, 0, 224, 1101, 0, 0, 1044, 1106, 0, 224,
+	1006, 1044, 247, 1001, 1039, 0, 1034, 1001, 1040, 0, 1035, 101, 0, 1041, 1036, 102,
+	1, 1043, 1038, 1002, 1042, 1, 1037, 4, 1044, 1106, 0, 0, 4, 26, 16, 55,
+	25, 8, 4, 99, 2, 21, 20, 20, 56, 26, 97, 81, 12, 2, 4, 9,
+	32, 7, 49, 54, 5, 18, 81, 16, 7, 88, 4, 23, 30, 66, 17, 31,
+	27, 29, 34, 26, 81, 62, 27, 81, 41, 84, 12, 53, 90, 79, 37, 22,
+	45, 27, 17, 39, 76, 1, 55, 58, 44, 20, 18, 57, 57, 20, 76, 47,
+	20, 44, 88, 26, 43, 36, 79, 12, 68, 30, 19, 71, 27, 21, 18, 75,
+	18, 9, 56, 29, 15, 84, 8, 74, 93, 1, 35, 91, 39, 32, 86, 9,
+	97, 54, 4, 22, 59, 13, 61, 31, 19, 97, 26, 82, 35, 73, 23, 77,
+	71, 59, 26, 76, 78, 73, 34, 85, 67, 26, 1, 66, 91, 79, 26, 95,
+	5, 75, 99, 29, 14, 23, 26, 8, 66, 97, 55, 21, 25, 49, 17, 99,
+	71, 37, 62, 21, 45, 46, 13, 29, 30, 24, 31, 63, 99, 12, 12, 63,
+	10, 64, 2, 76, 3, 8, 37, 94, 33, 12, 47, 65, 35, 65, 60, 12,
+	88, 8, 10, 49, 36, 12, 14, 4, 43, 82, 19, 16, 51, 52, 20, 17,
+	43, 18, 33, 49, 19, 93, 49, 29, 86, 10, 31, 92, 90, 44, 26, 97,
+	8, 63, 70, 81, 28, 17, 80, 23, 22, 79, 56, 33, 67, 61, 91, 37,
+	4, 83, 77, 16, 6, 8, 33, 66, 92, 46, 8, 34, 23, 81, 3, 93,
+	14, 23, 72, 20, 91, 16, 62, 79, 7, 27, 81, 10, 11, 44, 65, 24,
+	66, 77, 31, 12, 53, 15, 50, 84, 24, 70, 29, 62, 50, 5, 3, 88,
+	13, 52, 85, 42, 4, 15, 39, 82, 65, 18, 15, 58, 37, 71, 10, 13,
+	90, 98, 29, 59, 52, 3, 22, 13, 59, 91, 29, 23, 79, 1, 7, 24,
+	80, 79, 37, 31, 77, 17, 11, 64, 10, 9, 8, 74, 97, 6, 74, 35,
+	73, 44, 68, 29, 97, 3, 45, 73, 30, 28, 80, 9, 48, 73, 76, 7,
+	3, 77, 83, 8, 12, 41, 62, 44, 10, 21, 27, 74, 32, 95, 73, 4,
+	47, 71, 6, 67, 17, 57, 10, 67, 5, 25, 74, 18, 24, 57, 7, 61,
+	66, 4, 51, 14, 7, 44, 29, 79, 74, 11, 6, 49, 75, 32, 3, 98,
+	89, 63, 5, 15, 5, 74, 78, 37, 7, 77, 3, 13, 47, 9, 33, 76,
+	22, 47, 6, 72, 12, 35, 75, 39, 25, 87, 83, 37, 19, 91, 25, 45,
+	22, 30, 54, 83, 74, 22, 71, 19, 3, 3, 85, 74, 37, 95, 26, 67,
+	46, 10, 12, 96, 44, 50, 32, 90, 3, 28, 56, 24, 43, 4, 1, 65,
+	5, 9, 50, 22, 44, 88, 9, 48, 59, 21, 24, 54, 11, 35, 53, 28,
+	7, 82, 32, 24, 17, 45, 88, 34, 72, 95, 17, 9, 39, 29, 4, 55,
+	66, 95, 22, 62, 15, 71, 11, 39, 51, 37, 86, 49, 20, 10, 63, 31,
+	66, 59, 15, 55, 93, 3, 11, 28, 54, 30, 41, 20, 92, 7, 3, 12,
+	54, 49, 14, 33, 56, 89, 21, 26, 67, 20, 93, 7, 64, 3, 31, 60,
+	23, 51, 36, 30, 57, 20, 14, 28, 88, 4, 6, 69, 33, 65, 98, 35,
+	96, 80, 49, 25, 68, 78, 97, 30, 63, 35, 73, 89, 32, 64, 69, 10,
+	68, 96, 19, 89, 71, 41, 32, 31, 30, 90, 5, 71, 20, 53, 36, 51,
+	23, 87, 19, 25, 15, 34, 15, 48, 19, 25, 33, 14, 50, 64, 11, 96,
+	19, 34, 14, 44, 33, 29, 40, 16, 50, 90, 22, 34, 44, 17, 64, 63,
+	18, 86, 57, 29, 44, 22, 98, 16, 41, 20, 99, 34, 14, 51, 11, 4,
+	84, 91, 66, 27, 49, 6, 58, 34, 95, 62, 6, 45, 53, 27, 72, 4,
+	12, 40, 43, 17, 41, 93, 27, 30, 70, 31, 47, 87, 26, 64, 9, 63,
+	59, 73, 9, 11, 97, 35, 56, 73, 23, 58, 9, 49, 13, 88, 1, 87,
+	13, 54, 21, 94, 13, 69, 16, 39, 2, 10, 64, 13, 10, 19, 96, 2,
+	23, 1, 60, 99, 47, 12, 61, 37, 13, 70, 24, 48, 91, 7, 33, 51,
+	10, 25, 88, 33, 69, 29, 98, 16, 16, 60, 5, 29, 44, 17, 21, 41,
+	62, 65, 8, 61, 84, 27, 42, 78, 72, 23, 98, 16, 76, 98, 77, 37,
+	19, 49, 37, 93, 83, 97, 1, 63, 9, 63, 27, 66, 34, 74, 87, 58,
+	3, 90, 4, 48, 51, 67, 32, 66, 9, 56, 9, 44, 1, 67, 24, 49,
+	29, 58, 20, 70, 32, 73, 27, 82, 0, 0, 21, 21, 1, 10, 1, 0,
+	0, 0, 0, 0, 0,
+}
+
+var day_15_addresses []int = []int{
+	0, 2, 6, 9, 13, 16, 20, 23, 27, 30, 31, 35, 39, 43, 47, 51,
+	55, 58, 62, 66, 70, 74, 78, 81, 85, 89, 93, 97, 101, 104, 108, 112,
+	116, 120, 124, 127, 130, 134, 137, 141, 144, 148, 151, 155, 158, 162, 165, 169,
+	172, 176, 179, 183, 186, 190, 194, 198, 202, 206, 210, 214, 217, 221, 224, 227,
+	231, 235, 239, 243, 247, 249,
+}
+
+func run_day_15(native *intcode.Native, ip int) int {
+	for {
+		if native.Stale(ip) {
+			return ip
+		}
+
+		switch ip {
+		case 0:
+			// IN   [1033]
+			input, available := native.Input()
+			if !available {
+				return 0
+			}
+			native.Count++
+			if native.Write(1033, input) {
+				return 2
+			}
+			ip = 2
+		case 2:
+			// EQ   [1033], #1, [1032]
+			var value_0 int = native.Read(1033)
+			native.Count++
+			var result int = 0
+			if value_0 == (1) {
+				result = 1
+			}
+			if native.Write(1032, result) {
+				return 6
+			}
+			ip = 6
+		case 6:
+			// JNZ  [1032], #31
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 != 0 {
+				ip = (31)
+			} else {
+				ip = 9
+			}
+		case 9:
+			// EQ   [1033], #2, [1032]
+			var value_0 int = native.Read(1033)
+			native.Count++
+			var result int = 0
+			if value_0 == (2) {
+				result = 1
+			}
+			if native.Write(1032, result) {
+				return 13
+			}
+			ip = 13
+		case 13:
+			// JNZ  [1032], #58
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 != 0 {
+				ip = (58)
+			} else {
+				ip = 16
+			}
+		case 16:
+			// EQ   [1033], #3, [1032]
+			var value_0 int = native.Read(1033)
+			native.Count++
+			var result int = 0
+			if value_0 == (3) {
+				result = 1
+			}
+			if native.Write(1032, result) {
+				return 20
+			}
+			ip = 20
+		case 20:
+			// JNZ  [1032], #81
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 != 0 {
+				ip = (81)
+			} else {
+				ip = 23
+			}
+		case 23:
+			// EQ   [1033], #4, [1032]
+			var value_0 int = native.Read(1033)
+			native.Count++
+			var result int = 0
+			if value_0 == (4) {
+				result = 1
+			}
+			if native.Write(1032, result) {
+				return 27
+			}
+			ip = 27
+		case 27:
+			// JNZ  [1032], #104
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 != 0 {
+				ip = (104)
+			} else {
+				ip = 30
+			}
+		case 30:
+			// HLT
+			return 30
+		case 31:
+			// MUL  [1034], #1, [1039]
+			var value_0 int = native.Read(1034)
+			native.Count++
+			if native.Write(1039, value_0*(1)) {
+				return 35
+			}
+			ip = 35
+		case 35:
+			// MUL  [1036], #1, [1041]
+			var value_0 int = native.Read(1036)
+			native.Count++
+			if native.Write(1041, value_0*(1)) {
+				return 39
+			}
+			ip = 39
+		case 39:
+			// ADD  [1035], #-1, [1040]
+			var value_0 int = native.Read(1035)
+			native.Count++
+			if native.Write(1040, value_0+(-1)) {
+				return 43
+			}
+			ip = 43
+		case 43:
+			// EQ   [1038], #0, [1043]
+			var value_0 int = native.Read(1038)
+			native.Count++
+			var result int = 0
+			if value_0 == (0) {
+				result = 1
+			}
+			if native.Write(1043, result) {
+				return 47
+			}
+			ip = 47
+		case 47:
+			// MUL  #-1, [1043], [1032]
+			var value_1 int = native.Read(1043)
+			native.Count++
+			if native.Write(1032, (-1)*value_1) {
+				return 51
+			}
+			ip = 51
+		case 51:
+			// ADD  [1037], [1032], [1042]
+			var value_0 int = native.Read(1037)
+			var value_1 int = native.Read(1032)
+			native.Count++
+			if native.Write(1042, value_0+value_1) {
+				return 55
+			}
+			ip = 55
+		case 55:
+			// JZ   #0, #124
+			native.Count++
+			if (0) == 0 {
+				ip = (124)
+			} else {
+				ip = 58
+			}
+		case 58:
+			// ADD  [1034], #0, [1039]
+			var value_0 int = native.Read(1034)
+			native.Count++
+			if native.Write(1039, value_0+(0)) {
+				return 62
+			}
+			ip = 62
+		case 62:
+			// MUL  [1036], #1, [1041]
+			var value_0 int = native.Read(1036)
+			native.Count++
+			if native.Write(1041, value_0*(1)) {
+				return 66
+			}
+			ip = 66
+		case 66:
+			// ADD  [1035], #1, [1040]
+			var value_0 int = native.Read(1035)
+			native.Count++
+			if native.Write(1040, value_0+(1)) {
+				return 70
+			}
+			ip = 70
+		case 70:
+			// EQ   [1038], #0, [1043]
+			var value_0 int = native.Read(1038)
+			native.Count++
+			var result int = 0
+			if value_0 == (0) {
+				result = 1
+			}
+			if native.Write(1043, result) {
+				return 74
+			}
+			ip = 74
+		case 74:
+			// ADD  [1037], [1038], [1042]
+			var value_0 int = native.Read(1037)
+			var value_1 int = native.Read(1038)
+			native.Count++
+			if native.Write(1042, value_0+value_1) {
+				return 78
+			}
+			ip = 78
+		case 78:
+			// JZ   #0, #124
+			native.Count++
+			if (0) == 0 {
+				ip = (124)
+			} else {
+				ip = 81
+			}
+		case 81:
+			// ADD  [1034], #-1, [1039]
+			var value_0 int = native.Read(1034)
+			native.Count++
+			if native.Write(1039, value_0+(-1)) {
+				return 85
+			}
+			ip = 85
+		case 85:
+			// EQ   [1036], #0, [1041]
+			var value_0 int = native.Read(1036)
+			native.Count++
+			var result int = 0
+			if value_0 == (0) {
+				result = 1
+			}
+			if native.Write(1041, result) {
+				return 89
+			}
+			ip = 89
+		case 89:
+			// ADD  [1035], #0, [1040]
+			var value_0 int = native.Read(1035)
+			native.Count++
+			if native.Write(1040, value_0+(0)) {
+				return 93
+			}
+			ip = 93
+		case 93:
+			// MUL  #1, [1038], [1043]
+			var value_1 int = native.Read(1038)
+			native.Count++
+			if native.Write(1043, (1)*value_1) {
+				return 97
+			}
+			ip = 97
+		case 97:
+			// MUL  [1037], #1, [1042]
+			var value_0 int = native.Read(1037)
+			native.Count++
+			if native.Write(1042, value_0*(1)) {
+				return 101
+			}
+			ip = 101
+		case 101:
+			// JZ   #0, #124
+			native.Count++
+			if (0) == 0 {
+				ip = (124)
+			} else {
+				ip = 104
+			}
+		case 104:
+			// ADD  [1034], #1, [1039]
+			var value_0 int = native.Read(1034)
+			native.Count++
+			if native.Write(1039, value_0+(1)) {
+				return 108
+			}
+			ip = 108
+		case 108:
+			// EQ   [1036], #0, [1041]
+			var value_0 int = native.Read(1036)
+			native.Count++
+			var result int = 0
+			if value_0 == (0) {
+				result = 1
+			}
+			if native.Write(1041, result) {
+				return 112
+			}
+			ip = 112
+		case 112:
+			// MUL  #1, [1035], [1040]
+			var value_1 int = native.Read(1035)
+			native.Count++
+			if native.Write(1040, (1)*value_1) {
+				return 116
+			}
+			ip = 116
+		case 116:
+			// ADD  [1038], #0, [1043]
+			var value_0 int = native.Read(1038)
+			native.Count++
+			if native.Write(1043, value_0+(0)) {
+				return 120
+			}
+			ip = 120
+		case 120:
+			// MUL  [1037], #1, [1042]
+			var value_0 int = native.Read(1037)
+			native.Count++
+			if native.Write(1042, value_0*(1)) {
+				return 124
+			}
+			ip = 124
+		case 124:
+			// JZ   [1039], #217
+			var value_0 int = native.Read(1039)
+			native.Count++
+			if value_0 == 0 {
+				ip = (217)
+			} else {
+				ip = 127
+			}
+		case 127:
+			// JZ   [1040], #217
+			var value_0 int = native.Read(1040)
+			native.Count++
+			if value_0 == 0 {
+				ip = (217)
+			} else {
+				ip = 130
+			}
+		case 130:
+			// EQ   [1039], #40, [1032]
+			var value_0 int = native.Read(1039)
+			native.Count++
+			var result int = 0
+			if value_0 == (40) {
+				result = 1
+			}
+			if native.Write(1032, result) {
+				return 134
+			}
+			ip = 134
+		case 134:
+			// JNZ  [1032], #217
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 != 0 {
+				ip = (217)
+			} else {
+				ip = 137
+			}
+		case 137:
+			// EQ   [1040], #40, [1032]
+			var value_0 int = native.Read(1040)
+			native.Count++
+			var result int = 0
+			if value_0 == (40) {
+				result = 1
+			}
+			if native.Write(1032, result) {
+				return 141
+			}
+			ip = 141
+		case 141:
+			// JNZ  [1032], #217
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 != 0 {
+				ip = (217)
+			} else {
+				ip = 144
+			}
+		case 144:
+			// EQ   [1039], #5, [1032]
+			var value_0 int = native.Read(1039)
+			native.Count++
+			var result int = 0
+			if value_0 == (5) {
+				result = 1
+			}
+			if native.Write(1032, result) {
+				return 148
+			}
+			ip = 148
+		case 148:
+			// JZ   [1032], #165
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 == 0 {
+				ip = (165)
+			} else {
+				ip = 151
+			}
+		case 151:
+			// EQ   [1040], #35, [1032]
+			var value_0 int = native.Read(1040)
+			native.Count++
+			var result int = 0
+			if value_0 == (35) {
+				result = 1
+			}
+			if native.Write(1032, result) {
+				return 155
+			}
+			ip = 155
+		case 155:
+			// JZ   [1032], #165
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 == 0 {
+				ip = (165)
+			} else {
+				ip = 158
+			}
+		case 158:
+			// MUL  #1, #2, [1044]
+			native.Count++
+			if native.Write(1044, (2)) {
+				return 162
+			}
+			ip = 162
+		case 162:
+			// JZ   #0, #224
+			native.Count++
+			if (0) == 0 {
+				ip = (224)
+			} else {
+				ip = 165
+			}
+		case 165:
+			// MUL  [1041], [1043], [1032]
+			var value_0 int = native.Read(1041)
+			var value_1 int = native.Read(1043)
+			native.Count++
+			if native.Write(1032, value_0*value_1) {
+				return 169
+			}
+			ip = 169
+		case 169:
+			// JZ   [1032], #179
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 == 0 {
+				ip = (179)
+			} else {
+				ip = 172
+			}
+		case 172:
+			// MUL  #1, #1, [1044]
+			native.Count++
+			if native.Write(1044, (1)) {
+				return 176
+			}
+			ip = 176
+		case 176:
+			// JZ   #0, #224
+			native.Count++
+			if (0) == 0 {
+				ip = (224)
+			} else {
+				ip = 179
+			}
+		case 179:
+			// ADD  [1041], [1043], [1032]
+			var value_0 int = native.Read(1041)
+			var value_1 int = native.Read(1043)
+			native.Count++
+			if native.Write(1032, value_0+value_1) {
+				return 183
+			}
+			ip = 183
+		case 183:
+			// JZ   [1032], #217
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if value_0 == 0 {
+				ip = (217)
+			} else {
+				ip = 186
+			}
+		case 186:
+			// ADD  [1042], [1043], [1032]
+			var value_0 int = native.Read(1042)
+			var value_1 int = native.Read(1043)
+			native.Count++
+			if native.Write(1032, value_0+value_1) {
+				return 190
+			}
+			ip = 190
+		case 190:
+			// ADD  [1032], #-1, [1032]
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if native.Write(1032, value_0+(-1)) {
+				return 194
+			}
+			ip = 194
+		case 194:
+			// MUL  [1032], #39, [1032]
+			var value_0 int = native.Read(1032)
+			native.Count++
+			if native.Write(1032, value_0*(39)) {
+				return 198
+			}
+			ip = 198
+		case 198:
+			// ADD  [1032], [1039], [1032]
+			var value_0 int = native.Read(1032)
+			var value_1 int = native.Read(1039)
+			native.Count++
+			if native.Write(1032, value_0+value_1) {
+				return 202
+			}
+			ip = 202
+		case 202:
+			// ADD  #-1, [1032], [1032]
+			var value_1 int = native.Read(1032)
+			native.Count++
+			if native.Write(1032, (-1)+value_1) {
+				return 206
+			}
+			ip = 206
+		case 206:
+			// ADD  #252, [1032], [211]
+			var value_1 int = native.Read(1032)
+			native.Count++
+			if native.Write(211, (252)+value_1) {
+				return 210
+			}
+			ip = 210
+		case 210:
+			// LT   [0], #38, [1044]
+			var value_0 int = native.Read(0)
+			native.Count++
+			var result int = 0
+			if value_0 < (38) {
+				result = 1
+			}
+			if native.Write(1044, result) {
+				return 214
+			}
+			ip = 214
+		case 214:
+			// JZ   #0, #224
+			native.Count++
+			if (0) == 0 {
+				ip = (224)
+			} else {
+				ip = 217
+			}
+		case 217:
+			// ADD  #0, #0, [1044]
+			native.Count++
+			if native.Write(1044, (0)) {
+				return 221
+			}
+			ip = 221
+		case 221:
+			// JZ   #0, #224
+			native.Count++
+			if (0) == 0 {
+				ip = (224)
+			} else {
+				ip = 224
+			}
+		case 224:
+			// JZ   [1044], #247
+			var value_0 int = native.Read(1044)
+			native.Count++
+			if value_0 == 0 {
+				ip = (247)
+			} else {
+				ip = 227
+			}
+		case 227:
+			// ADD  [1039], #0, [1034]
+			var value_0 int = native.Read(1039)
+			native.Count++
+			if native.Write(1034, value_0+(0)) {
+				return 231
+			}
+			ip = 231
+		case 231:
+			// ADD  [1040], #0, [1035]
+			var value_0 int = native.Read(1040)
+			native.Count++
+			if native.Write(1035, value_0+(0)) {
+				return 235
+			}
+			ip = 235
+		case 235:
+			// ADD  #0, [1041], [1036]
+			var value_1 int = native.Read(1041)
+			native.Count++
+			if native.Write(1036, (0)+value_1) {
+				return 239
+			}
+			ip = 239
+		case 239:
+			// MUL  #1, [1043], [1038]
+			var value_1 int = native.Read(1043)
+			native.Count++
+			if native.Write(1038, (1)*value_1) {
+				return 243
+			}
+			ip = 243
+		case 243:
+			// MUL  [1042], #1, [1037]
+			var value_0 int = native.Read(1042)
+			native.Count++
+			if native.Write(1037, value_0*(1)) {
+				return 247
+			}
+			ip = 247
+		case 247:
+			// OUT  [1044]
+			var value_0 int = native.Read(1044)
+			native.Count++
+			native.Output(value_0)
+			ip = 249
+		case 249:
+			// JZ   #0, #0
+			native.Count++
+			if (0) == 0 {
+				ip = (0)
+			} else {
+				ip = 252
+			}
+		default:
+			return ip
+		}
+	}
+}
@@ -0,0 +1,1542 @@
+// Code generated by intcode.Compile. DO NOT EDIT.
+
+package compiled
+
+import "github.com/Sousa99/AdventOfCode2019/intcode"
+
+var day_19 *intcode.CompiledProgram = intcode.NewCompiledProgram(day_19_image, day_19_addresses, run_day_19)
+
+var day_19_image []int = []int{
+	109, 424, 203, 1, 21101, 11, 0, 0, 1105, 1, 282, 21102, 18, 1, 0, 1106,
+	0, 259, 1201, 1, 0, 221, 203, 1, 21102, 1, 31, 0, 1105, 1, 282, 21101,
+	38, 0, 0, 1106, 0, 259, 20102, 1, 23, 2, 21201, 1, 0, 3, 21101, 1,
+	0, 1, 21102, 57, 1, 0, 1105, 1, 303, 1201, 1, 0, 222, 21001, 221, 0,
+	3, 20101, 0, 221, 2, 21102, 1, 259, 1, 21101, 0, 80, 0, 1105, 1, 225,
+	21101, 76, 0, 2, 21102, 1, 91, 0, 1106, 0, 303, 2102, 1, 1, 223, 21002,
+	222, 1, 4, 21102, 1, 259, 3, 21101, 0, 225, 2, 21102, 225, 1, 1, 21102,
+	1, 118, 0, 1105, 1, 225, 21001, 222, 0, 3, 21102, 1, 54, 2, 21102, 1,
+	133, 0, 1106, 0, 303, 21202, 1, -1, 1, 22001, 223, 1, 1, 21101, 148, 0,
+	0, 1106, 0, 259, 1202, 1, 1, 223, 21001, 221, 0, 4, 20101, 0, 222, 3,
+	21101, 14, 0, 2, 1001, 132, -2, 224, 1002, 224, 2, 224, 1001, 224, 3, 224,
+	1002, 132, -1, 132, 1, 224, 132, 224, 21001, 224, 1, 1, 21101, 0, 195, 0,
+	106, 0, 108, 20207, 1, 223, 2, 20101, 0, 23, 1, 21101, 0, -1, 3, 21102,
+	1, 214, 0, 1105, 1, 303, 22101, 1, 1, 1, 204, 1, 99, 0, 0, 0,
+	0, 109, 5, 1202, -4, 1, 249, 22102, 1, -3, 1, 21201, -2, 0, 2, 21202,
+	-1, 1, 3, 21101, 0, 250, 0, 1106, 0, 225, 22101, 0, 1, -4, 109, -5,
+	2105, 1, 0, 109, 3, 22107, 0, -2, -1, 21202, -1, 2, -1, 21201, -1, -1,
+	-1, 22202, -1, -2, -2, 109, -3, 2105, 1, 0, 109, 3, 21207, -2, 0, -1,
+	1206, -1, 294, 104, 0, 99, 21201, -2, 0, -2, 109, -3, 2105, 1, 0, 109,
+	5, 22207, -3, -4, -1, 1206, -1, 346, 22201, -4, -3, -4, 21202, -3, -1, -1,
+	22201, -4, -1, 2, 21202, 2, -1, -1, 22201, -4, -1, 1, 22101, 0, -2, 3,
+	21102, 1, 343, 0, 1106, 0, 303, 1106, 0, 415, 22207, -2, -3, -1, 1206, -1,
+	387, 22201, -3, -2, -3, 21202, -2, -1, -1, 22201, -3, -1, 3, 21202, 3, -1,
+	-1, 22201, -3, -1, 2, 22102, 1, -4, 1, 21101, 0, 384, 0, 1105, 1, 303,
+	1106, 0, 415, 21202, -4, -1, -4, 22201, -4, -3, -4, 22202, -3, -2, -2, 22202,
+	-2, -4, -4, 22202, -3, -2, -3, 21202, -4, -1, -2, 22201, -3, -2, 1, 21202,
+	1, 1, -4, 109, -5, 2106, 0, 0,
+}
+
+var day_19_addresses []int = []int{
+	0, 2, 4, 8, 11, 15, 18, 22, 24, 28, 31, 35, 38, 42, 46, 50,
+	54, 57, 61, 65, 69, 73, 77, 80, 84, 88, 91, 95, 99, 103, 107, 111,
+	115, 118, 122, 126, 130, 133, 137, 141, 145, 148, 152, 156, 160, 164, 168, 172,
+	176, 180, 184, 188, 192, 195, 199, 203, 207, 211, 214, 218, 220, 225, 227, 231,
+	235, 239, 243, 247, 250, 254, 256, 259, 261, 265, 269, 273, 277, 279, 282, 284,
+	288, 291, 293, 294, 298, 300, 303, 305, 309, 312, 316, 320, 324, 328, 332, 336,
+	340, 343, 346, 350, 353, 357, 361, 365, 369, 373, 377, 381, 384, 387, 391, 395,
+	399, 403, 407, 411, 415, 419, 421,
+}
+
+func run_day_19(native *intcode.Native, ip int) int {
+	for {
+		if native.Stale(ip) {
+			return ip
+		}
+
+		switch ip {
+		case 0:
+			// ARB  #424
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (424)
+			ip = 2
+		case 2:
+			// IN   rb+1
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 2
+			}
+			input, available := native.Input()
+			if !available {
+				return 2
+			}
+			native.Count++
+			if native.Write(address_0, input) {
+				return 4
+			}
+			ip = 4
+		case 4:
+			// ADD  #11, #0, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 4
+			}
+			native.Count++
+			if native.Write(address_2, (11)) {
+				return 8
+			}
+			ip = 8
+		case 8:
+			// JNZ  #1, #282
+			native.Count++
+			if (1) != 0 {
+				ip = (282)
+			} else {
+				ip = 11
+			}
+		case 11:
+			// MUL  #18, #1, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 11
+			}
+			native.Count++
+			if native.Write(address_2, (18)) {
+				return 15
+			}
+			ip = 15
+		case 15:
+			// JZ   #0, #259
+			native.Count++
+			if (0) == 0 {
+				ip = (259)
+			} else {
+				ip = 18
+			}
+		case 18:
+			// ADD  rb+1, #0, [221]
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 18
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			if native.Write(221, value_0+(0)) {
+				return 22
+			}
+			ip = 22
+		case 22:
+			// IN   rb+1
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 22
+			}
+			input, available := native.Input()
+			if !available {
+				return 22
+			}
+			native.Count++
+			if native.Write(address_0, input) {
+				return 24
+			}
+			ip = 24
+		case 24:
+			// MUL  #1, #31, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 24
+			}
+			native.Count++
+			if native.Write(address_2, (31)) {
+				return 28
+			}
+			ip = 28
+		case 28:
+			// JNZ  #1, #282
+			native.Count++
+			if (1) != 0 {
+				ip = (282)
+			} else {
+				ip = 31
+			}
+		case 31:
+			// ADD  #38, #0, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 31
+			}
+			native.Count++
+			if native.Write(address_2, (38)) {
+				return 35
+			}
+			ip = 35
+		case 35:
+			// JZ   #0, #259
+			native.Count++
+			if (0) == 0 {
+				ip = (259)
+			} else {
+				ip = 38
+			}
+		case 38:
+			// MUL  #1, [23], rb+2
+			var value_1 int = native.Read(23)
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 38
+			}
+			native.Count++
+			if native.Write(address_2, (1)*value_1) {
+				return 42
+			}
+			ip = 42
+		case 42:
+			// ADD  rb+1, #0, rb+3
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 42
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 42
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(0)) {
+				return 46
+			}
+			ip = 46
+		case 46:
+			// ADD  #1, #0, rb+1
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 46
+			}
+			native.Count++
+			if native.Write(address_2, (1)) {
+				return 50
+			}
+			ip = 50
+		case 50:
+			// MUL  #57, #1, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 50
+			}
+			native.Count++
+			if native.Write(address_2, (57)) {
+				return 54
+			}
+			ip = 54
+		case 54:
+			// JNZ  #1, #303
+			native.Count++
+			if (1) != 0 {
+				ip = (303)
+			} else {
+				ip = 57
+			}
+		case 57:
+			// ADD  rb+1, #0, [222]
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 57
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			if native.Write(222, value_0+(0)) {
+				return 61
+			}
+			ip = 61
+		case 61:
+			// ADD  [221], #0, rb+3
+			var value_0 int = native.Read(221)
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 61
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(0)) {
+				return 65
+			}
+			ip = 65
+		case 65:
+			// ADD  #0, [221], rb+2
+			var value_1 int = native.Read(221)
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 65
+			}
+			native.Count++
+			if native.Write(address_2, (0)+value_1) {
+				return 69
+			}
+			ip = 69
+		case 69:
+			// MUL  #1, #259, rb+1
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 69
+			}
+			native.Count++
+			if native.Write(address_2, (259)) {
+				return 73
+			}
+			ip = 73
+		case 73:
+			// ADD  #0, #80, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 73
+			}
+			native.Count++
+			if native.Write(address_2, (80)) {
+				return 77
+			}
+			ip = 77
+		case 77:
+			// JNZ  #1, #225
+			native.Count++
+			if (1) != 0 {
+				ip = (225)
+			} else {
+				ip = 80
+			}
+		case 80:
+			// ADD  #76, #0, rb+2
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 80
+			}
+			native.Count++
+			if native.Write(address_2, (76)) {
+				return 84
+			}
+			ip = 84
+		case 84:
+			// MUL  #1, #91, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 84
+			}
+			native.Count++
+			if native.Write(address_2, (91)) {
+				return 88
+			}
+			ip = 88
+		case 88:
+			// JZ   #0, #303
+			native.Count++
+			if (0) == 0 {
+				ip = (303)
+			} else {
+				ip = 91
+			}
+		case 91:
+			// MUL  #1, rb+1, [223]
+			var address_1 int = native.RelativeBase + (1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 91
+			}
+			var value_1 int = native.Read(address_1)
+			native.Count++
+			if native.Write(223, (1)*value_1) {
+				return 95
+			}
+			ip = 95
+		case 95:
+			// MUL  [222], #1, rb+4
+			var value_0 int = native.Read(222)
+			var address_2 int = native.RelativeBase + (4)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 95
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(1)) {
+				return 99
+			}
+			ip = 99
+		case 99:
+			// MUL  #1, #259, rb+3
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 99
+			}
+			native.Count++
+			if native.Write(address_2, (259)) {
+				return 103
+			}
+			ip = 103
+		case 103:
+			// ADD  #0, #225, rb+2
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 103
+			}
+			native.Count++
+			if native.Write(address_2, (225)) {
+				return 107
+			}
+			ip = 107
+		case 107:
+			// MUL  #225, #1, rb+1
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 107
+			}
+			native.Count++
+			if native.Write(address_2, (225)) {
+				return 111
+			}
+			ip = 111
+		case 111:
+			// MUL  #1, #118, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 111
+			}
+			native.Count++
+			if native.Write(address_2, (118)) {
+				return 115
+			}
+			ip = 115
+		case 115:
+			// JNZ  #1, #225
+			native.Count++
+			if (1) != 0 {
+				ip = (225)
+			} else {
+				ip = 118
+			}
+		case 118:
+			// ADD  [222], #0, rb+3
+			var value_0 int = native.Read(222)
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 118
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(0)) {
+				return 122
+			}
+			ip = 122
+		case 122:
+			// MUL  #1, #54, rb+2
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 122
+			}
+			native.Count++
+			if native.Write(address_2, (54)) {
+				return 126
+			}
+			ip = 126
+		case 126:
+			// MUL  #1, #133, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 126
+			}
+			native.Count++
+			if native.Write(address_2, (133)) {
+				return 130
+			}
+			ip = 130
+		case 130:
+			// JZ   #0, #303
+			native.Count++
+			if (0) == 0 {
+				ip = (303)
+			} else {
+				ip = 133
+			}
+		case 133:
+			// MUL  rb+1, #-1, rb+1
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 133
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 133
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(-1)) {
+				return 137
+			}
+			ip = 137
+		case 137:
+			// ADD  [223], rb+1, rb+1
+			var value_0 int = native.Read(223)
+			var address_1 int = native.RelativeBase + (1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 137
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 137
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 141
+			}
+			ip = 141
+		case 141:
+			// ADD  #148, #0, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 141
+			}
+			native.Count++
+			if native.Write(address_2, (148)) {
+				return 145
+			}
+			ip = 145
+		case 145:
+			// JZ   #0, #259
+			native.Count++
+			if (0) == 0 {
+				ip = (259)
+			} else {
+				ip = 148
+			}
+		case 148:
+			// MUL  rb+1, #1, [223]
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 148
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			if native.Write(223, value_0*(1)) {
+				return 152
+			}
+			ip = 152
+		case 152:
+			// ADD  [221], #0, rb+4
+			var value_0 int = native.Read(221)
+			var address_2 int = native.RelativeBase + (4)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 152
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(0)) {
+				return 156
+			}
+			ip = 156
+		case 156:
+			// ADD  #0, [222], rb+3
+			var value_1 int = native.Read(222)
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 156
+			}
+			native.Count++
+			if native.Write(address_2, (0)+value_1) {
+				return 160
+			}
+			ip = 160
+		case 160:
+			// ADD  #14, #0, rb+2
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 160
+			}
+			native.Count++
+			if native.Write(address_2, (14)) {
+				return 164
+			}
+			ip = 164
+		case 164:
+			// ADD  [132], #-2, [224]
+			var value_0 int = native.Read(132)
+			native.Count++
+			if native.Write(224, value_0+(-2)) {
+				return 168
+			}
+			ip = 168
+		case 168:
+			// MUL  [224], #2, [224]
+			var value_0 int = native.Read(224)
+			native.Count++
+			if native.Write(224, value_0*(2)) {
+				return 172
+			}
+			ip = 172
+		case 172:
+			// ADD  [224], #3, [224]
+			var value_0 int = native.Read(224)
+			native.Count++
+			if native.Write(224, value_0+(3)) {
+				return 176
+			}
+			ip = 176
+		case 176:
+			// MUL  [132], #-1, [132]
+			var value_0 int = native.Read(132)
+			native.Count++
+			if native.Write(132, value_0*(-1)) {
+				return 180
+			}
+			ip = 180
+		case 180:
+			// ADD  [224], [132], [224]
+			var value_0 int = native.Read(224)
+			var value_1 int = native.Read(132)
+			native.Count++
+			if native.Write(224, value_0+value_1) {
+				return 184
+			}
+			ip = 184
+		case 184:
+			// ADD  [224], #1, rb+1
+			var value_0 int = native.Read(224)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 184
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(1)) {
+				return 188
+			}
+			ip = 188
+		case 188:
+			// ADD  #0, #195, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 188
+			}
+			native.Count++
+			if native.Write(address_2, (195)) {
+				return 192
+			}
+			ip = 192
+		case 192:
+			// JZ   #0, [108]
+			var value_1 int = native.Read(108)
+			native.Count++
+			if (0) == 0 {
+				ip = value_1
+			} else {
+				ip = 195
+			}
+		case 195:
+			// LT   rb+1, [223], rb+2
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 195
+			}
+			var value_0 int = native.Read(address_0)
+			var value_1 int = native.Read(223)
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 195
+			}
+			native.Count++
+			var result int = 0
+			if value_0 < value_1 {
+				result = 1
+			}
+			if native.Write(address_2, result) {
+				return 199
+			}
+			ip = 199
+		case 199:
+			// ADD  #0, [23], rb+1
+			var value_1 int = native.Read(23)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 199
+			}
+			native.Count++
+			if native.Write(address_2, (0)+value_1) {
+				return 203
+			}
+			ip = 203
+		case 203:
+			// ADD  #0, #-1, rb+3
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 203
+			}
+			native.Count++
+			if native.Write(address_2, (-1)) {
+				return 207
+			}
+			ip = 207
+		case 207:
+			// MUL  #1, #214, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 207
+			}
+			native.Count++
+			if native.Write(address_2, (214)) {
+				return 211
+			}
+			ip = 211
+		case 211:
+			// JNZ  #1, #303
+			native.Count++
+			if (1) != 0 {
+				ip = (303)
+			} else {
+				ip = 214
+			}
+		case 214:
+			// ADD  #1, rb+1, rb+1
+			var address_1 int = native.RelativeBase + (1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 214
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 214
+			}
+			native.Count++
+			if native.Write(address_2, (1)+value_1) {
+				return 218
+			}
+			ip = 218
+		case 218:
+			// OUT  rb+1
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 218
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			native.Output(value_0)
+			ip = 220
+		case 220:
+			// HLT
+			return 220
+		case 225:
+			// ARB  #5
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (5)
+			ip = 227
+		case 227:
+			// MUL  rb-4, #1, [249]
+			var address_0 int = native.RelativeBase + (-4)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 227
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			if native.Write(249, value_0*(1)) {
+				return 231
+			}
+			ip = 231
+		case 231:
+			// MUL  #1, rb-3, rb+1
+			var address_1 int = native.RelativeBase + (-3)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 231
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 231
+			}
+			native.Count++
+			if native.Write(address_2, (1)*value_1) {
+				return 235
+			}
+			ip = 235
+		case 235:
+			// ADD  rb-2, #0, rb+2
+			var address_0 int = native.RelativeBase + (-2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 235
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 235
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(0)) {
+				return 239
+			}
+			ip = 239
+		case 239:
+			// MUL  rb-1, #1, rb+3
+			var address_0 int = native.RelativeBase + (-1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 239
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 239
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(1)) {
+				return 243
+			}
+			ip = 243
+		case 243:
+			// ADD  #0, #250, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 243
+			}
+			native.Count++
+			if native.Write(address_2, (250)) {
+				return 247
+			}
+			ip = 247
+		case 247:
+			// JZ   #0, #225
+			native.Count++
+			if (0) == 0 {
+				ip = (225)
+			} else {
+				ip = 250
+			}
+		case 250:
+			// ADD  #0, rb+1, rb-4
+			var address_1 int = native.RelativeBase + (1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 250
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-4)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 250
+			}
+			native.Count++
+			if native.Write(address_2, (0)+value_1) {
+				return 254
+			}
+			ip = 254
+		case 254:
+			// ARB  #-5
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (-5)
+			ip = 256
+		case 256:
+			// JNZ  #1, rb+0
+			var address_1 int = native.RelativeBase + (0)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 256
+			}
+			var value_1 int = native.Read(address_1)
+			native.Count++
+			if (1) != 0 {
+				ip = value_1
+			} else {
+				ip = 259
+			}
+		case 259:
+			// ARB  #3
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (3)
+			ip = 261
+		case 261:
+			// LT   #0, rb-2, rb-1
+			var address_1 int = native.RelativeBase + (-2)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 261
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 261
+			}
+			native.Count++
+			var result int = 0
+			if (0) < value_1 {
+				result = 1
+			}
+			if native.Write(address_2, result) {
+				return 265
+			}
+			ip = 265
+		case 265:
+			// MUL  rb-1, #2, rb-1
+			var address_0 int = native.RelativeBase + (-1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 265
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 265
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(2)) {
+				return 269
+			}
+			ip = 269
+		case 269:
+			// ADD  rb-1, #-1, rb-1
+			var address_0 int = native.RelativeBase + (-1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 269
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 269
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(-1)) {
+				return 273
+			}
+			ip = 273
+		case 273:
+			// MUL  rb-1, rb-2, rb-2
+			var address_0 int = native.RelativeBase + (-1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 273
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-2)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 273
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 273
+			}
+			native.Count++
+			if native.Write(address_2, value_0*value_1) {
+				return 277
+			}
+			ip = 277
+		case 277:
+			// ARB  #-3
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (-3)
+			ip = 279
+		case 279:
+			// JNZ  #1, rb+0
+			var address_1 int = native.RelativeBase + (0)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 279
+			}
+			var value_1 int = native.Read(address_1)
+			native.Count++
+			if (1) != 0 {
+				ip = value_1
+			} else {
+				ip = 282
+			}
+		case 282:
+			// ARB  #3
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (3)
+			ip = 284
+		case 284:
+			// LT   rb-2, #0, rb-1
+			var address_0 int = native.RelativeBase + (-2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 284
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 284
+			}
+			native.Count++
+			var result int = 0
+			if value_0 < (0) {
+				result = 1
+			}
+			if native.Write(address_2, result) {
+				return 288
+			}
+			ip = 288
+		case 288:
+			// JZ   rb-1, #294
+			var address_0 int = native.RelativeBase + (-1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 288
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			if value_0 == 0 {
+				ip = (294)
+			} else {
+				ip = 291
+			}
+		case 291:
+			// OUT  #0
+			native.Count++
+			native.Output((0))
+			ip = 293
+		case 293:
+			// HLT
+			return 293
+		case 294:
+			// ADD  rb-2, #0, rb-2
+			var address_0 int = native.RelativeBase + (-2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 294
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 294
+			}
+			native.Count++
+			if native.Write(address_2, value_0+(0)) {
+				return 298
+			}
+			ip = 298
+		case 298:
+			// ARB  #-3
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (-3)
+			ip = 300
+		case 300:
+			// JNZ  #1, rb+0
+			var address_1 int = native.RelativeBase + (0)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 300
+			}
+			var value_1 int = native.Read(address_1)
+			native.Count++
+			if (1) != 0 {
+				ip = value_1
+			} else {
+				ip = 303
+			}
+		case 303:
+			// ARB  #5
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (5)
+			ip = 305
+		case 305:
+			// LT   rb-3, rb-4, rb-1
+			var address_0 int = native.RelativeBase + (-3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 305
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-4)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 305
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 305
+			}
+			native.Count++
+			var result int = 0
+			if value_0 < value_1 {
+				result = 1
+			}
+			if native.Write(address_2, result) {
+				return 309
+			}
+			ip = 309
+		case 309:
+			// JZ   rb-1, #346
+			var address_0 int = native.RelativeBase + (-1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 309
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			if value_0 == 0 {
+				ip = (346)
+			} else {
+				ip = 312
+			}
+		case 312:
+			// ADD  rb-4, rb-3, rb-4
+			var address_0 int = native.RelativeBase + (-4)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 312
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-3)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 312
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-4)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 312
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 316
+			}
+			ip = 316
+		case 316:
+			// MUL  rb-3, #-1, rb-1
+			var address_0 int = native.RelativeBase + (-3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 316
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 316
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(-1)) {
+				return 320
+			}
+			ip = 320
+		case 320:
+			// ADD  rb-4, rb-1, rb+2
+			var address_0 int = native.RelativeBase + (-4)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 320
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 320
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 320
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 324
+			}
+			ip = 324
+		case 324:
+			// MUL  rb+2, #-1, rb-1
+			var address_0 int = native.RelativeBase + (2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 324
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 324
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(-1)) {
+				return 328
+			}
+			ip = 328
+		case 328:
+			// ADD  rb-4, rb-1, rb+1
+			var address_0 int = native.RelativeBase + (-4)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 328
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 328
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 328
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 332
+			}
+			ip = 332
+		case 332:
+			// ADD  #0, rb-2, rb+3
+			var address_1 int = native.RelativeBase + (-2)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 332
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 332
+			}
+			native.Count++
+			if native.Write(address_2, (0)+value_1) {
+				return 336
+			}
+			ip = 336
+		case 336:
+			// MUL  #1, #343, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 336
+			}
+			native.Count++
+			if native.Write(address_2, (343)) {
+				return 340
+			}
+			ip = 340
+		case 340:
+			// JZ   #0, #303
+			native.Count++
+			if (0) == 0 {
+				ip = (303)
+			} else {
+				ip = 343
+			}
+		case 343:
+			// JZ   #0, #415
+			native.Count++
+			if (0) == 0 {
+				ip = (415)
+			} else {
+				ip = 346
+			}
+		case 346:
+			// LT   rb-2, rb-3, rb-1
+			var address_0 int = native.RelativeBase + (-2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 346
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-3)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 346
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 346
+			}
+			native.Count++
+			var result int = 0
+			if value_0 < value_1 {
+				result = 1
+			}
+			if native.Write(address_2, result) {
+				return 350
+			}
+			ip = 350
+		case 350:
+			// JZ   rb-1, #387
+			var address_0 int = native.RelativeBase + (-1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 350
+			}
+			var value_0 int = native.Read(address_0)
+			native.Count++
+			if value_0 == 0 {
+				ip = (387)
+			} else {
+				ip = 353
+			}
+		case 353:
+			// ADD  rb-3, rb-2, rb-3
+			var address_0 int = native.RelativeBase + (-3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 353
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-2)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 353
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 353
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 357
+			}
+			ip = 357
+		case 357:
+			// MUL  rb-2, #-1, rb-1
+			var address_0 int = native.RelativeBase + (-2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 357
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 357
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(-1)) {
+				return 361
+			}
+			ip = 361
+		case 361:
+			// ADD  rb-3, rb-1, rb+3
+			var address_0 int = native.RelativeBase + (-3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 361
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 361
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 361
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 365
+			}
+			ip = 365
+		case 365:
+			// MUL  rb+3, #-1, rb-1
+			var address_0 int = native.RelativeBase + (3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 365
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 365
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(-1)) {
+				return 369
+			}
+			ip = 369
+		case 369:
+			// ADD  rb-3, rb-1, rb+2
+			var address_0 int = native.RelativeBase + (-3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 369
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-1)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 369
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 369
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 373
+			}
+			ip = 373
+		case 373:
+			// MUL  #1, rb-4, rb+1
+			var address_1 int = native.RelativeBase + (-4)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 373
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 373
+			}
+			native.Count++
+			if native.Write(address_2, (1)*value_1) {
+				return 377
+			}
+			ip = 377
+		case 377:
+			// ADD  #0, #384, rb+0
+			var address_2 int = native.RelativeBase + (0)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 377
+			}
+			native.Count++
+			if native.Write(address_2, (384)) {
+				return 381
+			}
+			ip = 381
+		case 381:
+			// JNZ  #1, #303
+			native.Count++
+			if (1) != 0 {
+				ip = (303)
+			} else {
+				ip = 384
+			}
+		case 384:
+			// JZ   #0, #415
+			native.Count++
+			if (0) == 0 {
+				ip = (415)
+			} else {
+				ip = 387
+			}
+		case 387:
+			// MUL  rb-4, #-1, rb-4
+			var address_0 int = native.RelativeBase + (-4)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 387
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-4)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 387
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(-1)) {
+				return 391
+			}
+			ip = 391
+		case 391:
+			// ADD  rb-4, rb-3, rb-4
+			var address_0 int = native.RelativeBase + (-4)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 391
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-3)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 391
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-4)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 391
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 395
+			}
+			ip = 395
+		case 395:
+			// MUL  rb-3, rb-2, rb-2
+			var address_0 int = native.RelativeBase + (-3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 395
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-2)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 395
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 395
+			}
+			native.Count++
+			if native.Write(address_2, value_0*value_1) {
+				return 399
+			}
+			ip = 399
+		case 399:
+			// MUL  rb-2, rb-4, rb-4
+			var address_0 int = native.RelativeBase + (-2)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 399
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-4)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 399
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-4)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 399
+			}
+			native.Count++
+			if native.Write(address_2, value_0*value_1) {
+				return 403
+			}
+			ip = 403
+		case 403:
+			// MUL  rb-3, rb-2, rb-3
+			var address_0 int = native.RelativeBase + (-3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 403
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-2)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 403
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (-3)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 403
+			}
+			native.Count++
+			if native.Write(address_2, value_0*value_1) {
+				return 407
+			}
+			ip = 407
+		case 407:
+			// MUL  rb-4, #-1, rb-2
+			var address_0 int = native.RelativeBase + (-4)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 407
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-2)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 407
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(-1)) {
+				return 411
+			}
+			ip = 411
+		case 411:
+			// ADD  rb-3, rb-2, rb+1
+			var address_0 int = native.RelativeBase + (-3)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 411
+			}
+			var value_0 int = native.Read(address_0)
+			var address_1 int = native.RelativeBase + (-2)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 411
+			}
+			var value_1 int = native.Read(address_1)
+			var address_2 int = native.RelativeBase + (1)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 411
+			}
+			native.Count++
+			if native.Write(address_2, value_0+value_1) {
+				return 415
+			}
+			ip = 415
+		case 415:
+			// MUL  rb+1, #1, rb-4
+			var address_0 int = native.RelativeBase + (1)
+			if address_0 < 0 || address_0 > intcode.MAX_DENSE_ADDRESS {
+				return 415
+			}
+			var value_0 int = native.Read(address_0)
+			var address_2 int = native.RelativeBase + (-4)
+			if address_2 < 0 || address_2 > intcode.MAX_DENSE_ADDRESS {
+				return 415
+			}
+			native.Count++
+			if native.Write(address_2, value_0*(1)) {
+				return 419
+			}
+			ip = 419
+		case 419:
+			// ARB  #-5
+			native.Count++
+			native.RelativeBase = native.RelativeBase + (-5)
+			ip = 421
+		case 421:
+			// JZ   #0, rb+0
+			var address_1 int = native.RelativeBase + (0)
+			if address_1 < 0 || address_1 > intcode.MAX_DENSE_ADDRESS {
+				return 421
+			}
+			var value_1 int = native.Read(address_1)
+			native.Count++
+			if (0) == 0 {
+				ip = value_1
+			} else {
+				ip = 424
+			}
+		default:
+			return ip
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// Package compiled holds puzzle inputs compiled to Go by intcode.Compile, to
+// compare RunCompiled with the interpreter. Regenerate them with go generate
+// after changing the compiler.
+package compiled
+
+//go:generate go run ../cmd/compile -package compiled -name day_02 ../../day_02/input.txt day_02.go
+//go:generate go run ../cmd/compile -package compiled -name day_09 ../../day_09/input.txt day_09.go
+//go:generate go run ../cmd/compile -package compiled -name day_15 ../../day_15/input.txt day_15.go
+//go:generate go run ../cmd/compile -package compiled -name day_19 ../../day_19/input.txt day_19.go
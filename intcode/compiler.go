@@ -0,0 +1,185 @@
+package intcode
+
+import (
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+)
+
+// ----------------------- Compiler Struct Start -----------------------
+
+const IMPORT_PATH string = "github.com/Sousa99/AdventOfCode2019/intcode"
+
+// Compile translates a program image into Go source for package
+// package_name declaring name, a *CompiledProgram to be given to
+// RunCompiled. Every instruction the disassembler finds becomes a case of a
+// switch on the instruction pointer; everything else is left to the
+// interpreter.
+func Compile(codes []int, package_name string, name string) ([]byte, error) {
+	if !token.IsIdentifier(package_name) || package_name == "intcode" {
+		return nil, fmt.Errorf("package name not valid: ' %s '", package_name)
+	}
+	if !token.IsIdentifier(name) {
+		return nil, fmt.Errorf("program name not valid: ' %s '", name)
+	}
+
+	var compiled []Instruction = make([]Instruction, 0)
+	for _, instruction := range Disassemble(codes).Instructions() {
+		if compilable(instruction) {
+			compiled = append(compiled, instruction)
+		}
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "// Code generated by intcode.Compile. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&builder, "package %s\n\nimport \"%s\"\n\n", package_name, IMPORT_PATH)
+
+	fmt.Fprintf(&builder, "var %s *intcode.CompiledProgram = intcode.NewCompiledProgram(%s_image, %s_addresses, run_%s)\n\n", name, name, name, name)
+	fmt.Fprintf(&builder, "var %s_image []int = []int{%s}\n\n", name, join_values(codes))
+
+	var addresses []int = make([]int, 0, len(compiled))
+	for _, instruction := range compiled {
+		addresses = append(addresses, instruction.Address)
+	}
+	fmt.Fprintf(&builder, "var %s_addresses []int = []int{%s}\n\n", name, join_values(addresses))
+
+	fmt.Fprintf(&builder, "func run_%s(native *intcode.Native, ip int) int {\n", name)
+	fmt.Fprintf(&builder, "for {\nif native.Stale(ip) {\nreturn ip\n}\n\nswitch ip {\n")
+	for _, instruction := range compiled {
+		fmt.Fprintf(&builder, "case %d:\n// %s\n", instruction.Address, instruction)
+		compile_instruction(&builder, instruction)
+	}
+	fmt.Fprintf(&builder, "default:\nreturn ip\n}\n}\n}\n")
+
+	return format.Source([]byte(builder.String()))
+}
+
+// compilable leaves out instructions whose position arguments are negative
+// or past dense memory, so the interpreter reports them or reaches them in
+// sparse memory.
+func compilable(instruction Instruction) bool {
+	for arg_index, argument := range instruction.Arguments {
+		if instruction.Opcode.Tag(arg_index) == 0 && (argument < 0 || argument > MAX_DENSE_ADDRESS) {
+			return false
+		}
+	}
+	return true
+}
+
+// join_values lists values for a slice literal, sixteen per line.
+func join_values(values []int) string {
+	const VALUES_PER_LINE int = 16
+
+	var builder strings.Builder
+	for index, value := range values {
+		if index%VALUES_PER_LINE == 0 {
+			builder.WriteString("\n")
+		} else {
+			builder.WriteString(" ")
+		}
+		fmt.Fprintf(&builder, "%d,", value)
+	}
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+// operand_address gives the address cell of an argument, checking a relative
+// one is neither negative nor past dense memory first.
+func operand_address(builder *strings.Builder, instruction Instruction, arg_index int) string {
+	var argument int = instruction.Arguments[arg_index]
+	if instruction.Opcode.Tag(arg_index) == 0 {
+		return fmt.Sprintf("%d", argument)
+	}
+
+	var variable string = fmt.Sprintf("address_%d", arg_index)
+	fmt.Fprintf(builder, "var %s int = native.RelativeBase + (%d)\n", variable, argument)
+	fmt.Fprintf(builder, "if %s < 0 || %s > intcode.MAX_DENSE_ADDRESS {\nreturn %d\n}\n", variable, variable, instruction.Address)
+	return variable
+}
+
+// operand_value gives an expression for the value of a read argument.
+func operand_value(builder *strings.Builder, instruction Instruction, arg_index int) string {
+	if instruction.Opcode.Tag(arg_index) == 1 {
+		return fmt.Sprintf("(%d)", instruction.Arguments[arg_index])
+	}
+
+	var address string = operand_address(builder, instruction, arg_index)
+	var variable string = fmt.Sprintf("value_%d", arg_index)
+	fmt.Fprintf(builder, "var %s int = native.Read(%s)\n", variable, address)
+	return variable
+}
+
+func compile_instruction(builder *strings.Builder, instruction Instruction) {
+	var address int = instruction.Address
+	var next int = address + instruction.Opcode.Size()
+
+	// Written values leave when they change compiled code
+	var write = func(destination string, value string) {
+		fmt.Fprintf(builder, "if native.Write(%s, %s) {\nreturn %d\n}\nip = %d\n", destination, value, next, next)
+	}
+
+	switch instruction.Opcode.Code {
+	case 99:
+		fmt.Fprintf(builder, "return %d\n", address)
+
+	case 1, 2:
+		var first string = operand_value(builder, instruction, 0)
+		var second string = operand_value(builder, instruction, 1)
+		var destination string = operand_address(builder, instruction, 2)
+		var operator string = "+"
+		if instruction.Opcode.Code == 2 {
+			operator = "*"
+		}
+		var value string = first + " " + operator + " " + second
+
+		// Constants are folded here, as Go rejects constant expressions that
+		// overflow instead of wrapping them around
+		if instruction.Opcode.Tag(0) == 1 && instruction.Opcode.Tag(1) == 1 {
+			var folded int = instruction.Arguments[0] + instruction.Arguments[1]
+			if instruction.Opcode.Code == 2 {
+				folded = instruction.Arguments[0] * instruction.Arguments[1]
+			}
+			value = fmt.Sprintf("(%d)", folded)
+		}
+		fmt.Fprintf(builder, "native.Count++\n")
+		write(destination, value)
+
+	case 3:
+		var destination string = operand_address(builder, instruction, 0)
+		fmt.Fprintf(builder, "input, available := native.Input()\nif !available {\nreturn %d\n}\n", address)
+		fmt.Fprintf(builder, "native.Count++\n")
+		write(destination, "input")
+
+	case 4:
+		var value string = operand_value(builder, instruction, 0)
+		fmt.Fprintf(builder, "native.Count++\nnative.Output(%s)\nip = %d\n", value, next)
+
+	case 5, 6:
+		var condition string = operand_value(builder, instruction, 0)
+		var target string = operand_value(builder, instruction, 1)
+		var comparison string = "!="
+		if instruction.Opcode.Code == 6 {
+			comparison = "=="
+		}
+		fmt.Fprintf(builder, "native.Count++\nif %s %s 0 {\nip = %s\n} else {\nip = %d\n}\n", condition, comparison, target, next)
+
+	case 7, 8:
+		var first string = operand_value(builder, instruction, 0)
+		var second string = operand_value(builder, instruction, 1)
+		var destination string = operand_address(builder, instruction, 2)
+		var comparison string = "<"
+		if instruction.Opcode.Code == 8 {
+			comparison = "=="
+		}
+		fmt.Fprintf(builder, "native.Count++\nvar result int = 0\nif %s %s %s {\nresult = 1\n}\n", first, comparison, second)
+		write(destination, "result")
+
+	case 9:
+		var value string = operand_value(builder, instruction, 0)
+		fmt.Fprintf(builder, "native.Count++\nnative.RelativeBase = native.RelativeBase + %s\nip = %d\n", value, next)
+	}
+}
+
+// ----------------------- Compiler Struct End -----------------------
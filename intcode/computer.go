@@ -0,0 +1,494 @@
+// Package intcode implements the IntCode computer shared by every puzzle of
+// Advent of Code 2019 that runs an IntCode program.
+package intcode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ----------------------- IntCode Computer Struct Start -----------------------
+
+// State describes what the computer is doing between calls to Run.
+type State int
+
+const (
+	Booting State = iota
+	Running
+	Paused
+	AwaitingInput
+	Halted
+	Faulted
+)
+
+var state_names map[State]string = map[State]string{
+	Booting:       "booting",
+	Running:       "running",
+	Paused:        "paused",
+	AwaitingInput: "awaiting input",
+	Halted:        "halted",
+	Faulted:       "faulted",
+}
+
+func (state State) String() string {
+	name, is_set := state_names[state]
+	if !is_set {
+		return fmt.Sprintf("State(%d)", int(state))
+	}
+
+	return name
+}
+
+type IntCodeComputer struct {
+	state             State
+	input             []int
+	input_pointer     int
+	memory            paged_memory
+	memory_pointer    int
+	relative_pointer  int
+	output            []int
+	output_count      int
+	input_source      InputSource
+	output_sink       OutputSink
+	fault             error
+	instruction_count int
+	overflow_checked  bool
+	decode_cache      decode_cache
+	uncached          decoded_instruction
+	arguments         [3]int
+	tracer            Tracer
+	trace             TraceEvent
+	session           *Session
+}
+
+// Parse converts a comma separated IntCode program into its codes.
+func Parse(program string) ([]int, error) {
+	var split []string = strings.Split(strings.TrimSpace(program), ",")
+	var codes []int = make([]int, 0, len(split))
+	for _, code := range split {
+		code_converted, err := strconv.Atoi(strings.TrimSpace(code))
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code_converted)
+	}
+
+	return codes, nil
+}
+
+// New builds a computer ready to run the comma separated program.
+func New(program string) (IntCodeComputer, error) {
+	codes, err := Parse(program)
+	if err != nil {
+		return IntCodeComputer{}, err
+	}
+
+	return NewFromCodes(codes), nil
+}
+
+// NewWithMemory builds a computer for the comma separated program storing
+// its memory with the given model.
+func NewWithMemory(program string, model MemoryModel) (IntCodeComputer, error) {
+	codes, err := Parse(program)
+	if err != nil {
+		return IntCodeComputer{}, err
+	}
+
+	return NewFromCodesWithMemory(codes, model), nil
+}
+
+// NewFromCodes builds a computer whose memory is a copy of codes.
+func NewFromCodes(codes []int) IntCodeComputer {
+	return NewFromCodesWithMemory(codes, DenseMemory)
+}
+
+func NewFromCodesWithMemory(codes []int, model MemoryModel) IntCodeComputer {
+	return IntCodeComputer{state: Booting, input: make([]int, 0), memory: new_paged_memory(codes, 0, model), output: make([]int, 0)}
+}
+
+func (computer *IntCodeComputer) State() State {
+	return computer.state
+}
+
+// Pointer is the address of the next instruction to execute.
+func (computer *IntCodeComputer) Pointer() int {
+	return computer.memory_pointer
+}
+
+func (computer *IntCodeComputer) RelativeBase() int {
+	return computer.relative_pointer
+}
+
+// InstructionCount is how many instructions the computer has executed.
+func (computer *IntCodeComputer) InstructionCount() int {
+	return computer.instruction_count
+}
+
+// PendingInput returns the queued input values not yet consumed.
+func (computer *IntCodeComputer) PendingInput() []int {
+	return computer.input[computer.input_pointer:]
+}
+
+// AddInput queues values to be consumed by input instructions.
+func (computer *IntCodeComputer) AddInput(values ...int) {
+	computer.input = append(computer.input, values...)
+}
+
+// Output returns every value produced and not yet consumed.
+func (computer *IntCodeComputer) Output() []int {
+	return computer.output
+}
+
+// ConsumeOutput removes and returns the first count output values.
+func (computer *IntCodeComputer) ConsumeOutput(count int) []int {
+	var consumed []int = computer.output[:count]
+	computer.output = computer.output[count:]
+
+	return consumed
+}
+
+func (computer *IntCodeComputer) ClearOutput() {
+	computer.output = make([]int, 0)
+}
+
+func (computer *IntCodeComputer) ReadMemory(position int) (int, error) {
+	return computer.read(position)
+}
+
+func (computer *IntCodeComputer) WriteMemory(position int, value int) error {
+	return computer.write(position, value)
+}
+
+func (computer *IntCodeComputer) extend_memory(position int) error {
+	if position < 0 {
+		return fmt.Errorf("%w: ' %d '", ErrNegativeAddress, position)
+	}
+
+	return computer.memory.grow(position)
+}
+
+func (computer *IntCodeComputer) read(position int) (int, error) {
+	err := computer.extend_memory(position)
+	if err != nil {
+		return 0, err
+	}
+
+	return computer.memory.get(position), nil
+}
+
+func (computer *IntCodeComputer) write(position int, value int) error {
+	err := computer.extend_memory(position)
+	if err != nil {
+		return err
+	}
+
+	computer.memory.set(position, value)
+	computer.invalidate(position)
+	if computer.tracer != nil {
+		computer.trace.Writes = append(computer.trace.Writes, MemoryWrite{position, value})
+	}
+	return nil
+}
+
+// relative_address is the address a relative mode argument points to.
+func (computer *IntCodeComputer) relative_address(argument int) (int, error) {
+	if computer.overflow_checked && !addition_fits(computer.relative_pointer, argument) {
+		return 0, fmt.Errorf("%w: relative address ' %d ' + ' %d '", ErrOverflow, computer.relative_pointer, argument)
+	}
+	return computer.relative_pointer + argument, nil
+}
+
+// resolve_arguments turns the raw arguments into the values read and the
+// addresses written, in place so no instruction allocates.
+func (computer *IntCodeComputer) resolve_arguments(decoded *decoded_instruction) error {
+	for arg_index := 0; arg_index < decoded.number_arg; arg_index++ {
+		var argument int = decoded.operands[arg_index]
+
+		if arg_index >= decoded.number_arg-decoded.writing_args {
+			// Writing position
+			if decoded.modes[arg_index] == 2 {
+				// Relative mode writing
+				relative_argument, err := computer.relative_address(argument)
+				if err != nil {
+					return err
+				}
+				argument = relative_argument
+			}
+			computer.arguments[arg_index] = argument
+			continue
+		}
+
+		// Reading position
+		switch decoded.modes[arg_index] {
+		case 0:
+			// Position Mode
+			argument_value, err := computer.read(argument)
+			if err != nil {
+				return err
+			}
+			computer.arguments[arg_index] = argument_value
+		case 1:
+			// Immediate Mode
+			computer.arguments[arg_index] = argument
+		case 2:
+			// Relative mode
+			address, err := computer.relative_address(argument)
+			if err != nil {
+				return err
+			}
+			argument_value, err := computer.read(address)
+			if err != nil {
+				return err
+			}
+			computer.arguments[arg_index] = argument_value
+		}
+	}
+
+	return nil
+}
+
+// Step executes the instruction at the instruction pointer. An input
+// instruction with no queued input leaves the computer awaiting input. Once
+// an instruction fails the computer is faulted and keeps returning that error.
+func (computer *IntCodeComputer) Step() error {
+	return computer.step(true)
+}
+
+// step executes an instruction, keeping it decoded for next time when
+// cached.
+func (computer *IntCodeComputer) step(cached bool) error {
+	if computer.state == Halted {
+		return nil
+	} else if computer.state == Faulted {
+		return computer.fault
+	}
+	computer.state = Running
+
+	var pointer int = computer.memory_pointer
+	decoded, instruction, err := computer.fetch(pointer, cached)
+	if err == nil {
+		if computer.tracer != nil {
+			computer.trace = TraceEvent{Pointer: pointer, Instruction: instruction, RelativeBase: computer.relative_pointer}
+		}
+		err = computer.execute(decoded)
+	}
+
+	if err != nil {
+		// Keep pointing at the failing instruction
+		computer.memory_pointer = pointer
+		computer.state = Faulted
+		computer.fault = &InstructionError{err, pointer, instruction}
+		return computer.fault
+	}
+
+	// Input instructions waiting for a value did not execute
+	if computer.state != AwaitingInput {
+		computer.instruction_count = computer.instruction_count + 1
+		if computer.tracer != nil {
+			computer.trace.MemorySize = computer.memory.size
+			computer.tracer.Trace(computer.trace)
+		}
+	}
+
+	return nil
+}
+
+func (computer *IntCodeComputer) execute(decoded *decoded_instruction) error {
+	err := computer.resolve_arguments(decoded)
+	if err != nil {
+		return err
+	}
+
+	var arguments *[3]int = &computer.arguments
+	if computer.tracer != nil {
+		computer.trace.Opcode = decoded.code
+		computer.trace.Arguments = append([]int{}, arguments[:decoded.number_arg]...)
+	}
+
+	switch decoded.code {
+	// Halting
+	case 99:
+		computer.state = Halted
+
+	// Addition
+	case 1:
+		if computer.overflow_checked && !addition_fits(arguments[0], arguments[1]) {
+			return fmt.Errorf("%w: ' %d ' + ' %d '", ErrOverflow, arguments[0], arguments[1])
+		}
+		err = computer.write(arguments[2], arguments[0]+arguments[1])
+
+		//Advance pointer
+		computer.memory_pointer = computer.memory_pointer + 4
+
+	// Multiplication
+	case 2:
+		if computer.overflow_checked && !multiplication_fits(arguments[0], arguments[1]) {
+			return fmt.Errorf("%w: ' %d ' * ' %d '", ErrOverflow, arguments[0], arguments[1])
+		}
+		err = computer.write(arguments[2], arguments[0]*arguments[1])
+
+		//Advance pointer
+		computer.memory_pointer = computer.memory_pointer + 4
+
+	// Input
+	case 3:
+		value, available := computer.read_input()
+		if !available {
+			// Computer must wait for more input
+			computer.state = AwaitingInput
+		} else {
+			err = computer.write(arguments[0], value)
+
+			//Advance pointers
+			computer.memory_pointer = computer.memory_pointer + 2
+		}
+
+	// Output
+	case 4:
+		computer.write_output(arguments[0])
+
+		//Advance pointers
+		computer.memory_pointer = computer.memory_pointer + 2
+
+	// Jump if True
+	case 5:
+		if arguments[0] != 0 {
+			computer.memory_pointer = arguments[1]
+		} else {
+			computer.memory_pointer = computer.memory_pointer + 3
+		}
+
+	// Jump if False
+	case 6:
+		if arguments[0] == 0 {
+			computer.memory_pointer = arguments[1]
+		} else {
+			computer.memory_pointer = computer.memory_pointer + 3
+		}
+
+	// Less than
+	case 7:
+		if arguments[0] < arguments[1] {
+			err = computer.write(arguments[2], 1)
+		} else {
+			err = computer.write(arguments[2], 0)
+		}
+
+		//Advance pointers
+		computer.memory_pointer = computer.memory_pointer + 4
+
+	// Equals
+	case 8:
+		if arguments[0] == arguments[1] {
+			err = computer.write(arguments[2], 1)
+		} else {
+			err = computer.write(arguments[2], 0)
+		}
+
+		//Advance pointers
+		computer.memory_pointer = computer.memory_pointer + 4
+
+	// Adjust relative base
+	case 9:
+		if computer.overflow_checked && !addition_fits(computer.relative_pointer, arguments[0]) {
+			return fmt.Errorf("%w: relative base ' %d ' + ' %d '", ErrOverflow, computer.relative_pointer, arguments[0])
+		}
+		computer.relative_pointer = computer.relative_pointer + arguments[0]
+
+		//Advance pointers
+		computer.memory_pointer = computer.memory_pointer + 2
+	}
+
+	return err
+}
+
+// Run executes instructions until the program halts, needs more input or
+// fails, in which case the failure is returned.
+func (computer *IntCodeComputer) Run() error {
+	err := computer.Step()
+	for err == nil && computer.state == Running {
+		err = computer.Step()
+	}
+
+	return err
+}
+
+// RunUntilOutput runs like Run but pauses as soon as count new values have
+// been output.
+func (computer *IntCodeComputer) RunUntilOutput(count int) error {
+	var target int = computer.output_count + count
+
+	err := computer.Step()
+	for err == nil && computer.state == Running && computer.output_count < target {
+		err = computer.Step()
+	}
+
+	if computer.state == Running {
+		computer.state = Paused
+	}
+	return err
+}
+
+// RunFor runs like Run but pauses after executing max_instructions
+// instructions, returning how many were executed.
+func (computer *IntCodeComputer) RunFor(max_instructions int) (int, error) {
+	var executed int = 0
+	for executed < max_instructions && computer.state != Halted {
+
+		err := computer.Step()
+		if err != nil || computer.state == AwaitingInput {
+			// Instruction did not execute
+			return executed, err
+		}
+
+		executed = executed + 1
+		if computer.state != Running {
+			return executed, nil
+		}
+	}
+
+	if computer.state == Running {
+		computer.state = Paused
+	}
+	return executed, nil
+}
+
+// arguments_of gives how many arguments an operation takes and how many of
+// those, at the end, are addresses written to.
+func arguments_of(code int) (int, int) {
+	switch code {
+	case 1, 2, 7, 8:
+		return 3, 1
+	case 3:
+		return 1, 1
+	case 4, 9:
+		return 1, 0
+	case 5, 6:
+		return 2, 0
+	default:
+		return 0, 0
+	}
+}
+
+// MakeDeepCopy returns an independent copy of the computer, sharing no
+// input or output with the original. Memory pages and decoded instructions
+// are shared until either side changes them, so copies are cheap. Sources
+// and sinks set with SetInput and SetOutput are shared by both.
+func MakeDeepCopy(computer IntCodeComputer) IntCodeComputer {
+	copy_computer := computer
+
+	copy_computer.input = make([]int, len(computer.input))
+	copy_computer.memory = computer.memory.clone()
+	copy_computer.decode_cache = computer.decode_cache.clone()
+	// A session follows a single computer
+	copy_computer.session = nil
+	copy_computer.output = make([]int, len(computer.output))
+
+	copy(copy_computer.input, computer.input)
+	copy(copy_computer.output, computer.output)
+
+	return copy_computer
+}
+
+// ----------------------- IntCode Computer Struct End -----------------------
@@ -0,0 +1,390 @@
+package intcode
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+const DAY_05_COMPARE_TO_8 string = "3,21,1008,21,8,20,1005,20,22,107,8,21,20,1006,20,31,1106,0,36,98,0,0,1002,21,125,20,4,20,1105,1,46,104,999,1105,1,46,1101,1000,1,20,4,20,1105,1,46,98,99"
+const DAY_09_QUINE string = "109,1,204,-1,1001,100,1,100,1008,100,16,101,1006,101,0,99"
+
+type conformance_case struct {
+	name    string
+	program string
+	input   []int
+	output  []int
+	// Expected start of memory once halted, nil to skip
+	memory []int
+}
+
+var conformance_cases []conformance_case = []conformance_case{
+	// Day 2
+	{"day 2 add and multiply", "1,9,10,3,2,3,11,0,99,30,40,50", nil, []int{}, []int{3500, 9, 10, 70, 2, 3, 11, 0, 99, 30, 40, 50}},
+	{"day 2 add", "1,0,0,0,99", nil, []int{}, []int{2, 0, 0, 0, 99}},
+	{"day 2 multiply", "2,3,0,3,99", nil, []int{}, []int{2, 3, 0, 6, 99}},
+	{"day 2 multiply past the program", "2,4,4,5,99,0", nil, []int{}, []int{2, 4, 4, 5, 99, 9801}},
+	{"day 2 self modifying", "1,1,1,4,99,5,6,0,99", nil, []int{}, []int{30, 1, 1, 4, 2, 5, 6, 0, 99}},
+
+	// Day 5
+	{"day 5 echo", "3,0,4,0,99", []int{42}, []int{42}, nil},
+	{"day 5 immediate multiply", "1002,4,3,4,33", nil, []int{}, []int{1002, 4, 3, 4, 99}},
+	{"day 5 negative immediate", "1101,100,-1,4,0", nil, []int{}, []int{1101, 100, -1, 4, 99}},
+	{"day 5 position equal to 8", "3,9,8,9,10,9,4,9,99,-1,8", []int{8}, []int{1}, nil},
+	{"day 5 position not equal to 8", "3,9,8,9,10,9,4,9,99,-1,8", []int{7}, []int{0}, nil},
+	{"day 5 position less than 8", "3,9,7,9,10,9,4,9,99,-1,8", []int{5}, []int{1}, nil},
+	{"day 5 position not less than 8", "3,9,7,9,10,9,4,9,99,-1,8", []int{8}, []int{0}, nil},
+	{"day 5 immediate equal to 8", "3,3,1108,-1,8,3,4,3,99", []int{8}, []int{1}, nil},
+	{"day 5 immediate not equal to 8", "3,3,1108,-1,8,3,4,3,99", []int{9}, []int{0}, nil},
+	{"day 5 immediate less than 8", "3,3,1107,-1,8,3,4,3,99", []int{-3}, []int{1}, nil},
+	{"day 5 immediate not less than 8", "3,3,1107,-1,8,3,4,3,99", []int{8}, []int{0}, nil},
+	{"day 5 position jump on zero", "3,12,6,12,15,1,13,14,13,4,13,99,-1,0,1,9", []int{0}, []int{0}, nil},
+	{"day 5 position jump on non zero", "3,12,6,12,15,1,13,14,13,4,13,99,-1,0,1,9", []int{3}, []int{1}, nil},
+	{"day 5 immediate jump on zero", "3,3,1105,-1,9,1101,0,0,12,4,12,99,1", []int{0}, []int{0}, nil},
+	{"day 5 immediate jump on non zero", "3,3,1105,-1,9,1101,0,0,12,4,12,99,1", []int{-7}, []int{1}, nil},
+	{"day 5 below 8", DAY_05_COMPARE_TO_8, []int{7}, []int{999}, nil},
+	{"day 5 equal to 8", DAY_05_COMPARE_TO_8, []int{8}, []int{1000}, nil},
+	{"day 5 above 8", DAY_05_COMPARE_TO_8, []int{9}, []int{1001}, nil},
+
+	// Day 9
+	{"day 9 quine", DAY_09_QUINE, nil, []int{109, 1, 204, -1, 1001, 100, 1, 100, 1008, 100, 16, 101, 1006, 101, 0, 99}, nil},
+	{"day 9 sixteen digit product", "1102,34915192,34915192,7,4,7,99,0", nil, []int{1219070632396864}, nil},
+	{"day 9 large immediate", "104,1125899906842624,99", nil, []int{1125899906842624}, nil},
+	{"day 9 relative input write", "109,10,203,0,204,0,99", []int{42}, []int{42}, nil},
+	{"day 9 relative add write", "109,20,21101,3,4,1,204,1,99", nil, []int{7}, nil},
+	{"day 9 negative relative offset", "109,20,21101,3,4,-1,204,-1,99", nil, []int{7}, nil},
+	{"day 9 relative comparisons", "109,30,21107,1,2,0,21108,5,5,1,22201,0,1,2,204,2,99", nil, []int{2}, nil},
+	{"day 9 relative base adjusted twice", "109,30,109,-10,21101,6,0,0,204,0,4,20,99", nil, []int{6, 6}, nil},
+}
+
+func TestConformance(t *testing.T) {
+	for _, test := range conformance_cases {
+		t.Run(test.name, func(t *testing.T) {
+			computer, err := New(test.program)
+			if err != nil {
+				t.Fatal(err)
+			}
+			computer.AddInput(test.input...)
+
+			err = computer.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if computer.State() != Halted {
+				t.Fatalf("stopped ' %v ', expected halted", computer.State())
+			}
+			if !reflect.DeepEqual(computer.Output(), test.output) {
+				t.Fatalf("output %v, expected %v", computer.Output(), test.output)
+			}
+
+			for address, expected := range test.memory {
+				value, _ := computer.ReadMemory(address)
+				if value != expected {
+					t.Fatalf("memory ' %d ' holds %d, expected %d", address, value, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestConformanceBig(t *testing.T) {
+	for _, test := range conformance_cases {
+		t.Run(test.name, func(t *testing.T) {
+			computer, err := NewBig(test.program)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, value := range test.input {
+				computer.AddInput(big.NewInt(int64(value)))
+			}
+
+			err = computer.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var output []int = make([]int, 0)
+			for _, value := range computer.Output() {
+				output = append(output, int(value.Int64()))
+			}
+			if computer.State() != Halted || !reflect.DeepEqual(output, test.output) {
+				t.Fatalf("stopped ' %v ' with output %v, expected halted with %v", computer.State(), output, test.output)
+			}
+
+			for address, expected := range test.memory {
+				value, _ := computer.ReadMemory(address)
+				if value.Int64() != int64(expected) {
+					t.Fatalf("memory ' %d ' holds %v, expected %d", address, value, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestBigPastInt64(t *testing.T) {
+	var tests []struct {
+		name    string
+		program string
+		output  string
+	} = []struct {
+		name    string
+		program string
+		output  string
+	}{
+		// (2^63 - 1)^2 + 1
+		{"square", "1102,9223372036854775807,9223372036854775807,11,1001,11,1,11,4,11,99,0", "85070591730234615847396907784232501250"},
+		// -(2^63 - 1) * 2^63 - 2^63
+		{"negative", "1102,-9223372036854775807,4611686018427387904,15,1002,15,2,15,1001,15,-9223372036854775808,15,4,15,99,0", "-85070591730234615865843651857942052864"},
+		// 2^63 < 1, which would hold if 2^63 wrapped around
+		{"comparison", "1107,9223372036854775808,1,7,4,7,99,0", "0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			computer, err := NewBig(test.program)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = computer.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output := computer.Output(); len(output) != 1 || output[0].String() != test.output {
+				t.Fatalf("output %v, expected [%s]", output, test.output)
+			}
+		})
+	}
+
+	// The checked computer stops at the product instead
+	checked, err := New(tests[0].program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checked.SetOverflowCheck(true)
+	err = checked.Run()
+	var instruction_error *InstructionError
+	if !errors.Is(err, ErrOverflow) || !errors.As(err, &instruction_error) || instruction_error.Pointer != 0 {
+		t.Fatalf("got %v, expected an overflow at ' 0 '", err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	var tests []struct {
+		name    string
+		program string
+		kind    error
+		pointer int
+	} = []struct {
+		name    string
+		program string
+		kind    error
+		pointer int
+	}{
+		{"unknown opcode", "98,0,0,0", ErrUnknownOpcode, 0},
+		{"unknown opcode after others", "1101,1,1,0,42", ErrUnknownOpcode, 4},
+		{"zero opcode", "0", ErrUnknownOpcode, 0},
+		{"parameter mode 3", "301,0,0,0,99", ErrInvalidParameterMode, 0},
+		{"parameter mode 3 on write", "30001,0,0,0,99", ErrInvalidParameterMode, 0},
+		{"immediate write", "11101,1,1,5,99", ErrWriteImmediateMode, 0},
+		{"immediate input write", "103,0,99", ErrWriteImmediateMode, 0},
+		{"negative read", "1,-1,0,0,99", ErrNegativeAddress, 0},
+		{"negative write", "1101,1,1,-1,99", ErrNegativeAddress, 0},
+		{"negative relative read", "109,-5,204,0,99", ErrNegativeAddress, 2},
+		{"negative jump target", "1105,1,-2", ErrNegativeAddress, -2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			computer, err := New(test.program)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = computer.Run()
+			if !errors.Is(err, test.kind) {
+				t.Fatalf("got %v, expected %v", err, test.kind)
+			}
+
+			var instruction_error *InstructionError
+			if !errors.As(err, &instruction_error) || instruction_error.Pointer != test.pointer {
+				t.Fatalf("got %v, expected it at ' %d '", err, test.pointer)
+			}
+			if computer.State() != Faulted || computer.Pointer() != test.pointer {
+				t.Fatalf("stopped ' %v ' at ' %d ', expected faulted at ' %d '", computer.State(), computer.Pointer(), test.pointer)
+			}
+
+			// A faulted computer keeps failing the same way
+			if again := computer.Step(); again != err {
+				t.Fatalf("stepping again gave %v", again)
+			}
+		})
+	}
+}
+
+func TestOverflowChecked(t *testing.T) {
+	var tests []struct {
+		name    string
+		program string
+		pointer int
+	} = []struct {
+		name    string
+		program string
+		pointer int
+	}{
+		{"addition", "1101,9223372036854775807,1,0,99", 0},
+		{"multiplication", "1102,4611686018427387904,2,0,99", 0},
+		{"relative base", "109,9223372036854775807,109,1,99", 2},
+		// Unchecked, rb+x wraps around to address 0
+		{"relative read", "109,-9223372036854775808,204,-9223372036854775808,99", 2},
+		{"relative write", "109,-9223372036854775808,203,-9223372036854775808,99", 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			computer, err := New(test.program)
+			if err != nil {
+				t.Fatal(err)
+			}
+			computer.SetOverflowCheck(true)
+			computer.AddInput(1)
+
+			err = computer.Run()
+			var instruction_error *InstructionError
+			if !errors.Is(err, ErrOverflow) || !errors.As(err, &instruction_error) || instruction_error.Pointer != test.pointer {
+				t.Fatalf("got %v, expected an overflow at ' %d '", err, test.pointer)
+			}
+
+			// Unchecked, the same program wraps around without failing
+			computer, _ = New(test.program)
+			computer.AddInput(1)
+			err = computer.Run()
+			if err != nil {
+				t.Fatalf("unchecked run failed with ' %v '", err)
+			}
+		})
+	}
+}
+
+func TestAwaitingInputResumes(t *testing.T) {
+	computer, err := New("3,0,4,0,3,0,4,0,99")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without input nothing executes
+	err = computer.Run()
+	if err != nil || computer.State() != AwaitingInput || computer.Pointer() != 0 || computer.InstructionCount() != 0 {
+		t.Fatalf("got %v, ' %v ' at ' %d ' after %d instructions", err, computer.State(), computer.Pointer(), computer.InstructionCount())
+	}
+	err = computer.Run()
+	if err != nil || computer.State() != AwaitingInput || computer.Pointer() != 0 {
+		t.Fatalf("running again without input gave %v, ' %v ' at ' %d '", err, computer.State(), computer.Pointer())
+	}
+
+	computer.AddInput(5)
+	err = computer.Run()
+	if err != nil || computer.State() != AwaitingInput || computer.Pointer() != 4 {
+		t.Fatalf("got %v, ' %v ' at ' %d '", err, computer.State(), computer.Pointer())
+	}
+	if !reflect.DeepEqual(computer.ConsumeOutput(1), []int{5}) {
+		t.Fatalf("first output not 5")
+	}
+
+	computer.AddInput(6, 7)
+	err = computer.Run()
+	if err != nil || computer.State() != Halted {
+		t.Fatalf("got %v, ' %v '", err, computer.State())
+	}
+	if !reflect.DeepEqual(computer.Output(), []int{6}) || !reflect.DeepEqual(computer.PendingInput(), []int{7}) {
+		t.Fatalf("output %v with pending %v", computer.Output(), computer.PendingInput())
+	}
+}
+
+// Day 23 feeds -1 whenever a machine has nothing to read.
+func TestAwaitingInputPolling(t *testing.T) {
+	computer, err := New("3,20,1008,20,-1,21,1005,21,0,4,20,99")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for round := 0; round < 3; round++ {
+		computer.AddInput(-1)
+		err = computer.Run()
+		if err != nil || computer.State() != AwaitingInput {
+			t.Fatalf("round %d: got %v, ' %v '", round, err, computer.State())
+		}
+	}
+
+	computer.AddInput(12)
+	err = computer.Run()
+	if err != nil || computer.State() != Halted || !reflect.DeepEqual(computer.Output(), []int{12}) {
+		t.Fatalf("got %v, ' %v ' with output %v", err, computer.State(), computer.Output())
+	}
+}
+
+func TestRunUntilOutputPauses(t *testing.T) {
+	computer, err := New("104,1,104,2,104,3,99")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = computer.RunUntilOutput(2)
+	if err != nil || computer.State() != Paused || !reflect.DeepEqual(computer.ConsumeOutput(2), []int{1, 2}) {
+		t.Fatalf("got %v, ' %v '", err, computer.State())
+	}
+	err = computer.RunUntilOutput(2)
+	if err != nil || computer.State() != Halted || !reflect.DeepEqual(computer.Output(), []int{3}) {
+		t.Fatalf("got %v, ' %v ' with output %v", err, computer.State(), computer.Output())
+	}
+}
+
+func TestRunForPauses(t *testing.T) {
+	computer, err := New("1101,1,1,20,1101,2,2,21,3,22,99")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executed, err := computer.RunFor(1)
+	if err != nil || executed != 1 || computer.State() != Paused || computer.Pointer() != 4 {
+		t.Fatalf("got %d, %v, ' %v ' at ' %d '", executed, err, computer.State(), computer.Pointer())
+	}
+	executed, err = computer.RunFor(10)
+	if err != nil || executed != 1 || computer.State() != AwaitingInput {
+		t.Fatalf("got %d, %v, ' %v '", executed, err, computer.State())
+	}
+	computer.AddInput(0)
+	executed, err = computer.RunFor(10)
+	if err != nil || executed != 2 || computer.State() != Halted {
+		t.Fatalf("got %d, %v, ' %v '", executed, err, computer.State())
+	}
+}
+
+// Day 7 chains amplifiers through channels, blocking until input arrives.
+func TestRunWithChannelsFeedback(t *testing.T) {
+	computer, err := New("3,20,1001,20,1,20,4,20,1008,20,5,21,1006,21,0,99")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var input chan int = make(chan int)
+	var output chan int = make(chan int)
+	var failure chan error = make(chan error, 1)
+	go func() { failure <- computer.RunWithChannels(input, output) }()
+
+	var received []int = make([]int, 0)
+	input <- 0
+	for value := range output {
+		received = append(received, value)
+		if value < 5 {
+			input <- value
+		}
+	}
+
+	if err := <-failure; err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(received, []int{1, 2, 3, 4, 5}) || computer.State() != Halted {
+		t.Fatalf("received %v, ' %v '", received, computer.State())
+	}
+}
@@ -0,0 +1,478 @@
+package intcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ----------------------- Debugger Struct Start -----------------------
+
+// Stop tells why the debugger handed control back.
+type Stop int
+
+const (
+	StopStep Stop = iota
+	StopBreakpoint
+	StopWatchpoint
+	StopInput
+	StopHalt
+	StopFault
+)
+
+var stop_names map[Stop]string = map[Stop]string{
+	StopStep:       "stepped",
+	StopBreakpoint: "breakpoint",
+	StopWatchpoint: "watchpoint",
+	StopInput:      "awaiting input",
+	StopHalt:       "halted",
+	StopFault:      "faulted",
+}
+
+func (stop Stop) String() string {
+	return stop_names[stop]
+}
+
+const DEFAULT_HISTORY_SIZE int = 64
+
+// Debugger controls a computer one instruction at a time, stopping on
+// breakpoints and watchpoints and remembering the last instructions executed.
+type Debugger struct {
+	computer          *IntCodeComputer
+	breakpoints       map[int]bool
+	opcode_breaks     map[int]bool
+	watchpoints       map[int]int
+	history           []Instruction
+	history_size      int
+	last_watch_change string
+}
+
+func NewDebugger(computer *IntCodeComputer) *Debugger {
+	return &Debugger{computer, make(map[int]bool), make(map[int]bool), make(map[int]int), make([]Instruction, 0), DEFAULT_HISTORY_SIZE, ""}
+}
+
+func (debugger *Debugger) AddBreakpoint(address int) {
+	debugger.breakpoints[address] = true
+}
+
+func (debugger *Debugger) RemoveBreakpoint(address int) {
+	delete(debugger.breakpoints, address)
+}
+
+// AddOpcodeBreakpoint stops before any instruction of the operation code.
+func (debugger *Debugger) AddOpcodeBreakpoint(code int) {
+	debugger.opcode_breaks[code] = true
+}
+
+func (debugger *Debugger) RemoveOpcodeBreakpoint(code int) {
+	delete(debugger.opcode_breaks, code)
+}
+
+// AddWatchpoint stops after any instruction changing the memory cell.
+func (debugger *Debugger) AddWatchpoint(address int) {
+	debugger.watchpoints[address] = debugger.peek(address)
+}
+
+func (debugger *Debugger) RemoveWatchpoint(address int) {
+	delete(debugger.watchpoints, address)
+}
+
+// History returns up to count of the last executed instructions, the most
+// recent first.
+func (debugger *Debugger) History(count int) []Instruction {
+	var instructions []Instruction = make([]Instruction, 0, count)
+	for index := len(debugger.history) - 1; index >= 0 && len(instructions) < count; index-- {
+		instructions = append(instructions, debugger.history[index])
+	}
+
+	return instructions
+}
+
+// decode reads the instruction at the address without growing memory.
+func (debugger *Debugger) decode(address int) (Instruction, bool) {
+	var window []int = make([]int, 0, 4)
+	for index := 0; index < 4 && address+index < debugger.computer.memory.size; index++ {
+		window = append(window, debugger.peek(address+index))
+	}
+
+	instruction, valid := decode_instruction(window, 0)
+	instruction.Address = address
+	return instruction, valid
+}
+
+// peek reads memory without growing it.
+func (debugger *Debugger) peek(address int) int {
+	if address < 0 {
+		return debugger.computer.memory.default_value
+	}
+
+	return debugger.computer.memory.get(address)
+}
+
+// current decodes the instruction at the instruction pointer.
+func (debugger *Debugger) current() (Instruction, bool) {
+	var pointer int = debugger.computer.memory_pointer
+	opcode, err := GetOpcode(debugger.peek(pointer))
+	if err != nil {
+		return Instruction{pointer, Opcode{debugger.peek(pointer), nil}, nil}, false
+	}
+
+	var arguments []int = make([]int, 0, opcode.Size()-1)
+	for index := 1; index < opcode.Size(); index++ {
+		arguments = append(arguments, debugger.peek(pointer+index))
+	}
+
+	return Instruction{pointer, opcode, arguments}, true
+}
+
+// Step executes a single instruction.
+func (debugger *Debugger) Step() (Stop, error) {
+	if debugger.computer.state == Halted {
+		return StopHalt, nil
+	}
+	instruction, _ := debugger.current()
+
+	err := debugger.computer.Step()
+	if err != nil {
+		return StopFault, err
+	}
+
+	switch debugger.computer.state {
+	case AwaitingInput:
+		return StopInput, nil
+	case Halted:
+		debugger.remember(instruction)
+		return StopHalt, nil
+	}
+
+	debugger.remember(instruction)
+	if debugger.watch_changed() {
+		return StopWatchpoint, nil
+	}
+	return StopStep, nil
+}
+
+// Continue executes instructions until a breakpoint or watchpoint is hit, or
+// the computer halts, fails or needs input. A breakpoint on the current
+// instruction is stepped over.
+func (debugger *Debugger) Continue() (Stop, error) {
+	var first bool = true
+	for {
+		if !first && debugger.at_breakpoint() {
+			return StopBreakpoint, nil
+		}
+		first = false
+
+		stop, err := debugger.Step()
+		if stop != StopStep {
+			return stop, err
+		}
+	}
+}
+
+func (debugger *Debugger) at_breakpoint() bool {
+	if debugger.breakpoints[debugger.computer.memory_pointer] {
+		return true
+	}
+
+	return debugger.opcode_breaks[debugger.peek(debugger.computer.memory_pointer)%100]
+}
+
+func (debugger *Debugger) remember(instruction Instruction) {
+	debugger.history = append(debugger.history, instruction)
+	if len(debugger.history) > debugger.history_size {
+		debugger.history = debugger.history[len(debugger.history)-debugger.history_size:]
+	}
+}
+
+func (debugger *Debugger) watch_changed() bool {
+	var changed bool = false
+	for address, old_value := range debugger.watchpoints {
+		var new_value int = debugger.peek(address)
+		if new_value != old_value {
+			debugger.watchpoints[address] = new_value
+			debugger.last_watch_change = fmt.Sprintf("[%d] changed from %d to %d", address, old_value, new_value)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// ----------------------- Debugger Struct End -----------------------
+
+// ----------------------- Debugger REPL Start -----------------------
+
+const DEBUGGER_HELP string = `Commands:
+  s, step [n]              execute n instructions (1 by default)
+  c, continue              run until a breakpoint, watchpoint, input or halt
+  b, break <addr>          break before the instruction at addr
+  b, break op <mnemonic>   break before any instruction of that operation
+  d, delete <addr>         remove a breakpoint (or: delete op <mnemonic>)
+  w, watch <addr>          stop whenever memory cell addr changes
+  unwatch <addr>           remove a watchpoint
+  h, history [n]           last n executed instructions, most recent first
+  l, list [addr] [n]       disassemble n instructions from addr (ip by default)
+  x, mem <addr> [n]        dump n memory cells from addr
+  p, poke <addr> <value>   patch a memory cell
+  r, regs                  instruction pointer, relative base and state
+  i, input <values...>     queue input values
+  a, ascii <text>          queue text and a new line as ASCII input
+  o, output                print and clear the output so far
+  save <file>              checkpoint the machine to a file
+  load <file>              resume the machine checkpointed in a file
+  q, quit                  leave the debugger`
+
+// Repl reads debugger commands line by line until quit or the end of input.
+func (debugger *Debugger) Repl(reader io.Reader, writer io.Writer) {
+	scanner := bufio.NewScanner(reader)
+
+	debugger.print_current(writer)
+	fmt.Fprint(writer, "(debug) ")
+	for scanner.Scan() {
+		var fields []string = strings.Fields(scanner.Text())
+		if len(fields) != 0 {
+			if fields[0] == "q" || fields[0] == "quit" {
+				return
+			}
+
+			err := debugger.execute_command(fields, writer)
+			if err != nil {
+				fmt.Fprintln(writer, err)
+			}
+		}
+
+		fmt.Fprint(writer, "(debug) ")
+	}
+	fmt.Fprintln(writer)
+}
+
+func (debugger *Debugger) execute_command(fields []string, writer io.Writer) error {
+	var arguments []string = fields[1:]
+	var numbers []int = make([]int, 0, len(arguments))
+	for _, argument := range arguments {
+		number, err := strconv.Atoi(argument)
+		if err == nil {
+			numbers = append(numbers, number)
+		}
+	}
+
+	switch fields[0] {
+	case "s", "step":
+		var count int = 1
+		if len(numbers) > 0 {
+			count = numbers[0]
+		}
+
+		var stop Stop = StopStep
+		var err error = nil
+		for index := 0; index < count && stop == StopStep; index++ {
+			stop, err = debugger.Step()
+		}
+		debugger.report(stop, err, writer)
+
+	case "c", "continue":
+		stop, err := debugger.Continue()
+		debugger.report(stop, err, writer)
+
+	case "b", "break", "d", "delete":
+		var adding bool = fields[0] == "b" || fields[0] == "break"
+		if len(arguments) == 2 && arguments[0] == "op" {
+			code, is_mnemonic := mnemonic_code(arguments[1])
+			if !is_mnemonic {
+				return fmt.Errorf("unknown mnemonic ' %s '", arguments[1])
+			}
+			if adding {
+				debugger.AddOpcodeBreakpoint(code)
+			} else {
+				debugger.RemoveOpcodeBreakpoint(code)
+			}
+			return nil
+		}
+
+		if len(numbers) != 1 {
+			return fmt.Errorf("%s needs an address", fields[0])
+		}
+		if adding {
+			debugger.AddBreakpoint(numbers[0])
+		} else {
+			debugger.RemoveBreakpoint(numbers[0])
+		}
+
+	case "w", "watch", "unwatch":
+		if len(numbers) != 1 {
+			return fmt.Errorf("%s needs an address", fields[0])
+		}
+		if fields[0] == "unwatch" {
+			debugger.RemoveWatchpoint(numbers[0])
+		} else {
+			debugger.AddWatchpoint(numbers[0])
+		}
+
+	case "h", "history":
+		var count int = 10
+		if len(numbers) > 0 {
+			count = numbers[0]
+		}
+		for _, instruction := range debugger.History(count) {
+			fmt.Fprintf(writer, "%05d  %s\n", instruction.Address, instruction)
+		}
+
+	case "l", "list":
+		var address int = debugger.computer.memory_pointer
+		var count int = 10
+		if len(numbers) > 0 {
+			address = numbers[0]
+		}
+		if len(numbers) > 1 {
+			count = numbers[1]
+		}
+
+		for index := 0; index < count && address < debugger.computer.memory.size; index++ {
+			instruction, valid := debugger.decode(address)
+			var marker string = " "
+			if address == debugger.computer.memory_pointer {
+				marker = ">"
+			}
+			if !valid {
+				fmt.Fprintf(writer, "%s %05d  DATA %d\n", marker, address, debugger.peek(address))
+				address = address + 1
+				continue
+			}
+
+			fmt.Fprintf(writer, "%s %05d  %s\n", marker, address, instruction)
+			address = address + instruction.Opcode.Size()
+		}
+
+	case "x", "mem":
+		if len(numbers) < 1 {
+			return fmt.Errorf("%s needs an address", fields[0])
+		}
+		var count int = 8
+		if len(numbers) > 1 {
+			count = numbers[1]
+		}
+
+		for index := 0; index < count; index++ {
+			if index%8 == 0 {
+				if index != 0 {
+					fmt.Fprintln(writer)
+				}
+				fmt.Fprintf(writer, "%05d:", numbers[0]+index)
+			}
+			fmt.Fprintf(writer, " %d", debugger.peek(numbers[0]+index))
+		}
+		fmt.Fprintln(writer)
+
+	case "p", "poke":
+		if len(numbers) != 2 {
+			return fmt.Errorf("%s needs an address and a value", fields[0])
+		}
+		err := debugger.computer.WriteMemory(numbers[0], numbers[1])
+		if err != nil {
+			return err
+		}
+		// Patching a watched cell is not a change made by the program
+		if _, is_watched := debugger.watchpoints[numbers[0]]; is_watched {
+			debugger.watchpoints[numbers[0]] = numbers[1]
+		}
+
+	case "r", "regs":
+		fmt.Fprintf(writer, "ip: %d  rb: %d  state: %v  pending input: %v\n", debugger.computer.memory_pointer, debugger.computer.relative_pointer, debugger.computer.state, debugger.computer.PendingInput())
+		debugger.print_current(writer)
+
+	case "i", "input":
+		if len(numbers) != len(arguments) || len(numbers) == 0 {
+			return fmt.Errorf("%s needs integer values", fields[0])
+		}
+		debugger.computer.AddInput(numbers...)
+
+	case "a", "ascii":
+		var text string = strings.Join(arguments, " ") + "\n"
+		for _, character := range text {
+			debugger.computer.AddInput(int(character))
+		}
+
+	case "o", "output":
+		var output []int = debugger.computer.Output()
+		fmt.Fprintf(writer, "%v\n", output)
+
+		var printable bool = len(output) > 0
+		for _, value := range output {
+			printable = printable && (value == 10 || (value >= 32 && value < 127))
+		}
+		if printable {
+			for _, value := range output {
+				fmt.Fprintf(writer, "%c", rune(value))
+			}
+			fmt.Fprintln(writer)
+		}
+		debugger.computer.ClearOutput()
+
+	case "save", "load":
+		if len(arguments) != 1 {
+			return fmt.Errorf("%s needs a file name", fields[0])
+		}
+		if fields[0] == "save" {
+			return debugger.computer.SaveFile(arguments[0])
+		}
+
+		computer, err := LoadFile(arguments[0])
+		if err != nil {
+			return err
+		}
+		// Keep whatever the machine was wired to
+		computer.input_source = debugger.computer.input_source
+		computer.output_sink = debugger.computer.output_sink
+		computer.tracer = debugger.computer.tracer
+		computer.session = debugger.computer.session
+		*debugger.computer = computer
+
+		// The old history and watched values belong to another run
+		debugger.history = debugger.history[:0]
+		for address := range debugger.watchpoints {
+			debugger.AddWatchpoint(address)
+		}
+		debugger.print_current(writer)
+
+	case "help":
+		fmt.Fprintln(writer, DEBUGGER_HELP)
+
+	default:
+		return fmt.Errorf("unknown command ' %s ', try help", fields[0])
+	}
+
+	return nil
+}
+
+func (debugger *Debugger) report(stop Stop, err error, writer io.Writer) {
+	switch stop {
+	case StopFault:
+		fmt.Fprintln(writer, err)
+		return
+	case StopWatchpoint:
+		fmt.Fprintf(writer, "Watchpoint: %s\n", debugger.last_watch_change)
+	case StopStep:
+	default:
+		fmt.Fprintf(writer, "Stopped: %v\n", stop)
+	}
+
+	debugger.print_current(writer)
+}
+
+func (debugger *Debugger) print_current(writer io.Writer) {
+	if debugger.computer.state == Halted {
+		return
+	}
+
+	instruction, valid := debugger.current()
+	if !valid {
+		fmt.Fprintf(writer, "> %05d  DATA %d\n", instruction.Address, instruction.Opcode.Code)
+		return
+	}
+	fmt.Fprintf(writer, "> %05d  %s\n", instruction.Address, instruction)
+}
+
+// ----------------------- Debugger REPL End -----------------------
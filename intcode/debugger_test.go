@@ -0,0 +1,102 @@
+package intcode
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Counts its input down to zero, outputting every value on the way
+const COUNTDOWN_SOURCE string = `
+        IN    [value]
+loop:   ADD   [value], #-1, [value]
+        OUT   [value]
+        JNZ   [value], loop
+        HLT
+value:  db    0
+`
+
+// run_debugger drives the debugger over the countdown with a script of
+// commands and returns everything it printed.
+func run_debugger(t *testing.T, script string) string {
+	codes, err := Assemble(COUNTDOWN_SOURCE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var computer IntCodeComputer = NewFromCodes(codes)
+	var output bytes.Buffer
+	NewDebugger(&computer).Repl(strings.NewReader(script), &output)
+	return output.String()
+}
+
+func TestDebuggerRepl(t *testing.T) {
+	var file string = filepath.Join(t.TempDir(), "countdown.snapshot")
+
+	var tests []struct {
+		name     string
+		script   string
+		expected []string
+	} = []struct {
+		name     string
+		script   string
+		expected []string
+	}{
+		{"step", "i 3\ns 2\nr", []string{"> 00006  OUT  [12]", "ip: 6  rb: 0  state: running  pending input: []"}},
+		{"awaiting input", "c", []string{"Stopped: awaiting input\n> 00000  IN   [12]"}},
+		{
+			"address breakpoint", "i 2\nb 8\nc\nc\nc",
+			[]string{"Stopped: breakpoint\n> 00008  JNZ  [12], #2", "Stopped: breakpoint\n> 00008", "Stopped: halted"},
+		},
+		{"deleted breakpoint", "i 2\nb 8\nd 8\nc\nr", []string{"Stopped: halted", "ip: 11"}},
+		{
+			"opcode breakpoint", "i 2\nb op out\nc\nc\nd op out\nc",
+			[]string{"Stopped: breakpoint\n> 00006  OUT  [12]", "Stopped: breakpoint\n> 00006", "Stopped: halted"},
+		},
+		{
+			"watchpoint", "w 12\ni 3\nc\nc",
+			[]string{"Watchpoint: [12] changed from 0 to 3\n> 00002", "Watchpoint: [12] changed from 3 to 2\n> 00006"},
+		},
+		{"unwatched", "w 12\nunwatch 12\ni 1\nc", []string{"Stopped: halted"}},
+		{"poked watchpoint", "i 1\ns\nw 12\np 12 5\ns", []string{"Watchpoint: [12] changed from 5 to 4"}},
+		{"history", "i 1\nc\nh 3", []string{"00011  HLT\n00008  JNZ  [12], #2\n00006  OUT  [12]\n(debug)"}},
+		{"poke and memory", "p 12 5\nx 10 4", []string{"00010: 2 99 5 0\n"}},
+		{"list", "l 6 3", []string{"> 00000  IN   [12]\n(debug)   00006  OUT  [12]\n  00008  JNZ  [12], #2\n  00011  HLT\n"}},
+		{"output", "i 2\nc\no", []string{"[1 0]\n"}},
+		{"ascii input", "a hi\nr", []string{"pending input: [104 105 10]"}},
+		{
+			"save and load", fmt.Sprintf("i 2\ns 2\nsave %s\ns 5\nload %s\nr", file, file),
+			[]string{"> 00011  HLT", "> 00006  OUT  [12]\n(debug) ip: 6  rb: 0  state: running"},
+		},
+		{"fault", "p 0 42\ns", []string{ErrUnknownOpcode.Error() + ": ' 42 '"}},
+		{
+			"errors", "b\nbogus\nb op jmp\ni x\np 1\nx\nsave",
+			[]string{
+				"b needs an address", "unknown command ' bogus ', try help", "unknown mnemonic ' jmp '",
+				"i needs integer values", "p needs an address and a value", "x needs an address", "save needs a file name",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output string = run_debugger(t, test.script)
+			var rest string = output
+			for _, expected := range test.expected {
+				var index int = strings.Index(rest, expected)
+				if index == -1 {
+					t.Fatalf("no ' %s ' in order in\n%s", expected, output)
+				}
+				rest = rest[index+len(expected):]
+			}
+		})
+	}
+}
+
+func TestDebuggerQuitStopsReading(t *testing.T) {
+	if output := run_debugger(t, "q\ni 1\nc"); strings.Contains(output, "halted") {
+		t.Fatalf("ran after quitting:\n%s", output)
+	}
+}
@@ -0,0 +1,176 @@
+package intcode
+
+import "fmt"
+
+// ----------------------- Decode Cache Struct Start -----------------------
+
+// decoded_instruction is an instruction ready to execute: its operation and
+// the mode and raw value of each argument. The computer keeps one per
+// address it executed until something writes over its cells.
+type decoded_instruction struct {
+	value        int
+	code         int
+	number_arg   int
+	writing_args int
+	modes        [3]int
+	operands     [3]int
+	valid        bool
+}
+
+// Instructions span at most four cells
+const MAX_INSTRUCTION_SIZE int = 4
+
+// Programs far bigger than any puzzle run uncached past this address
+const MAX_CACHED_ADDRESSES int = 1 << 16
+
+// Decoded instructions are kept in pages smaller than memory pages, as a fork
+// copies a whole page the first time it changes an instruction in it
+const DECODE_PAGE_SIZE int = 64
+
+type decode_page struct {
+	instructions [DECODE_PAGE_SIZE]decoded_instruction
+	frozen       bool
+}
+
+// decode_cache shares its pages between forks the way paged_memory does, so
+// a fork starts with everything its original already decoded.
+type decode_cache struct {
+	pages []*decode_page
+}
+
+func (cache *decode_cache) lookup(pointer int) *decoded_instruction {
+	var page_index int = pointer / DECODE_PAGE_SIZE
+	if pointer < 0 || page_index >= len(cache.pages) || cache.pages[page_index] == nil {
+		return nil
+	}
+
+	var decoded *decoded_instruction = &cache.pages[page_index].instructions[pointer%DECODE_PAGE_SIZE]
+	if !decoded.valid {
+		return nil
+	}
+	return decoded
+}
+
+// writable returns the page, copying it first when it is shared.
+func (cache *decode_cache) writable(page_index int) *decode_page {
+	var page *decode_page = cache.pages[page_index]
+	if page == nil {
+		page = &decode_page{}
+		cache.pages[page_index] = page
+	} else if page.frozen {
+		page = &decode_page{page.instructions, false}
+		cache.pages[page_index] = page
+	}
+	return page
+}
+
+func (cache *decode_cache) store(pointer int, decoded decoded_instruction) *decoded_instruction {
+	var page *decode_page = cache.writable(pointer / DECODE_PAGE_SIZE)
+	page.instructions[pointer%DECODE_PAGE_SIZE] = decoded
+	return &page.instructions[pointer%DECODE_PAGE_SIZE]
+}
+
+// clone shares every page with the copy until either side changes it.
+func (cache *decode_cache) clone() decode_cache {
+	if cache.pages == nil {
+		return decode_cache{}
+	}
+
+	for _, page := range cache.pages {
+		if page != nil {
+			page.frozen = true
+		}
+	}
+
+	var pages []*decode_page = make([]*decode_page, len(cache.pages))
+	copy(pages, cache.pages)
+	return decode_cache{pages}
+}
+
+// decode validates an instruction value the same way GetOpcode does, adding
+// the check that arguments written are not in immediate mode, without
+// allocating.
+func decode(value int) (decoded_instruction, error) {
+	var decoded decoded_instruction = decoded_instruction{}
+	decoded.value = value
+	decoded.code = value % 100
+
+	var tags int = value / 100
+	for index := 0; tags != 0; index++ {
+		var tag int = tags % 10
+		// Only valid tags
+		if tag < 0 || tag > 2 {
+			return decoded, fmt.Errorf("%w: ' %d '", ErrInvalidParameterMode, tag)
+		}
+
+		if index < len(decoded.modes) {
+			decoded.modes[index] = tag
+		}
+		tags = tags / 10
+	}
+
+	// Only valid opcodes
+	if (decoded.code < 1 || decoded.code > 9) && decoded.code != 99 {
+		return decoded, fmt.Errorf("%w: ' %d '", ErrUnknownOpcode, decoded.code)
+	}
+
+	decoded.number_arg, decoded.writing_args = arguments_of(decoded.code)
+	for arg_index := decoded.number_arg - decoded.writing_args; arg_index < decoded.number_arg; arg_index++ {
+		if decoded.modes[arg_index] == 1 {
+			return decoded, ErrWriteImmediateMode
+		}
+	}
+
+	decoded.valid = true
+	return decoded, nil
+}
+
+// fetch returns the decoded instruction at the pointer and its raw value,
+// from the cache when its cells were not written since it was last decoded.
+// Only addresses inside the memory the program started with are cached, up
+// to MAX_CACHED_ADDRESSES, and only when cached.
+func (computer *IntCodeComputer) fetch(pointer int, cached bool) (*decoded_instruction, int, error) {
+	if decoded := computer.decode_cache.lookup(pointer); decoded != nil {
+		return decoded, decoded.value, nil
+	}
+
+	instruction, err := computer.read(pointer)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	decoded, err := decode(instruction)
+	if err != nil {
+		return nil, instruction, err
+	}
+	for arg_index := 0; arg_index < decoded.number_arg; arg_index++ {
+		decoded.operands[arg_index], err = computer.read(pointer + 1 + arg_index)
+		if err != nil {
+			return nil, instruction, err
+		}
+	}
+
+	if cached && computer.decode_cache.pages == nil {
+		var cached_addresses int = min(computer.memory.size, MAX_CACHED_ADDRESSES)
+		computer.decode_cache.pages = make([]*decode_page, (cached_addresses+DECODE_PAGE_SIZE-1)/DECODE_PAGE_SIZE)
+	}
+	if cached && pointer < len(computer.decode_cache.pages)*DECODE_PAGE_SIZE {
+		return computer.decode_cache.store(pointer, decoded), instruction, nil
+	}
+
+	computer.uncached = decoded
+	return &computer.uncached, instruction, nil
+}
+
+// invalidate forgets every cached instruction covering the address.
+func (computer *IntCodeComputer) invalidate(address int) {
+	for start := max(address-MAX_INSTRUCTION_SIZE+1, 0); start <= address; start++ {
+		var decoded *decoded_instruction = computer.decode_cache.lookup(start)
+		if decoded == nil || start+decoded.number_arg < address {
+			continue
+		}
+		computer.decode_cache.writable(start / DECODE_PAGE_SIZE).instructions[start%DECODE_PAGE_SIZE].valid = false
+	}
+}
+
+// ----------------------- Decode Cache Struct End -----------------------
@@ -0,0 +1,128 @@
+package intcode
+
+import (
+	"runtime"
+	"testing"
+)
+
+// Instructions a run executes before its steps are measured, so the pages it
+// copies on its first writes are left out
+const WARM_UP_INSTRUCTIONS int = 10000
+
+func start_run(b *testing.B, base IntCodeComputer, input []int) IntCodeComputer {
+	var computer IntCodeComputer = MakeDeepCopy(base)
+	computer.AddInput(input...)
+	// Room for the output, which belongs to the run rather than to its steps
+	computer.output = make([]int, 0, 16)
+	for step := 0; step < WARM_UP_INSTRUCTIONS && computer.State() != Halted; step++ {
+		err := computer.Step()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	return computer
+}
+
+// Steps through a long running program, one instruction per operation,
+// starting it over with the timer stopped whenever it halts. Executing an
+// instruction must not allocate.
+func bench_instructions(b *testing.B, day string, input ...int) {
+	var base IntCodeComputer = load_day(b, day)
+	var computer IntCodeComputer = start_run(b, base, input)
+
+	var before runtime.MemStats
+	var after runtime.MemStats
+	var allocations uint64 = 0
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	runtime.ReadMemStats(&before)
+	for iteration := 0; iteration < b.N; iteration++ {
+		if computer.State() == Halted {
+			b.StopTimer()
+			runtime.ReadMemStats(&after)
+			allocations = allocations + after.Mallocs - before.Mallocs
+			computer = start_run(b, base, input)
+			runtime.ReadMemStats(&before)
+			b.StartTimer()
+		}
+
+		err := computer.Step()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	runtime.ReadMemStats(&after)
+	allocations = allocations + after.Mallocs - before.Mallocs
+
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N), "ns/instruction")
+	b.ReportMetric(float64(allocations)/float64(b.N), "allocs/instruction")
+	if allocations != 0 {
+		b.Fatalf("%d allocations in %d instructions", allocations, b.N)
+	}
+}
+
+// Runs whole programs forked from the same computer, as day 19 does for
+// every probe. Allocations are those of forking: the computer's own slices
+// and the pages it writes to first.
+func bench_runs(b *testing.B, day string, input ...int) {
+	var base IntCodeComputer = load_day(b, day)
+	var instructions int = 0
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		var computer IntCodeComputer = MakeDeepCopy(base)
+		computer.AddInput(input...)
+		err := computer.Run()
+		if err != nil {
+			b.Fatal(err)
+		}
+		instructions = instructions + computer.InstructionCount()
+	}
+
+	b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(instructions), "ns/instruction")
+}
+
+func BenchmarkInstructionsDay09(b *testing.B) {
+	bench_instructions(b, "day_09", 2)
+}
+
+func BenchmarkRunsDay19(b *testing.B) {
+	bench_runs(b, "day_19", 10, 20)
+}
+
+func TestStepDoesNotAllocate(t *testing.T) {
+	var base IntCodeComputer = load_day(t, "day_09")
+	base.AddInput(2)
+	for step := 0; step < WARM_UP_INSTRUCTIONS; step++ {
+		base.Step()
+	}
+
+	// A fork reuses what the original decoded
+	var fork IntCodeComputer = MakeDeepCopy(base)
+	for step := 0; step < WARM_UP_INSTRUCTIONS; step++ {
+		fork.Step()
+	}
+	var shared int = 0
+	for page_index, page := range fork.decode_cache.pages {
+		if page != nil && page == base.decode_cache.pages[page_index] {
+			shared = shared + 1
+		}
+	}
+	if shared == 0 {
+		t.Fatalf("fork shares no decoded instructions")
+	}
+
+	for _, computer := range []*IntCodeComputer{&base, &fork} {
+		var allocations float64 = testing.AllocsPerRun(100, func() {
+			for step := 0; step < 100; step++ {
+				computer.Step()
+			}
+		})
+		if allocations != 0 || computer.State() != Running {
+			t.Fatalf("%v allocations per 100 instructions, ' %v '", allocations, computer.State())
+		}
+	}
+}
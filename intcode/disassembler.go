@@ -0,0 +1,363 @@
+package intcode
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ----------------------- Disassembler Struct Start -----------------------
+
+// Instruction is an instruction found at an address of a program image,
+// together with the raw values of its arguments.
+type Instruction struct {
+	Address   int
+	Opcode    Opcode
+	Arguments []int
+}
+
+// Listing separates a program image into code and data.
+type Listing struct {
+	codes        []int
+	instructions map[int]Instruction
+	labels       map[int]string
+	written      map[int]bool
+	swept        bool
+}
+
+// Disassemble walks every instruction reachable from address 0. Jumps are
+// only followed to immediate targets, and an immediate sum or product pushed
+// through the relative base is taken as a return address, which is how the
+// puzzle programs call their functions. Everything left over is data.
+// Jump targets and return addresses are labelled.
+//
+// Cells the walked code writes to in position mode may hold something else
+// at runtime, so immediate conditions and targets written that way are taken
+// as unknown. When the walk meets code it cannot follow, a jump to an unknown
+// target other than a return through rb+0, or an instruction that only
+// decodes once rewritten, it falls back to a linear sweep of the rest of the
+// image, listing whatever decodes as code. Swept listings may show data as
+// code, and code out of step until it decodes in line again.
+func Disassemble(codes []int) Listing {
+	var listing Listing = Listing{codes, nil, nil, make(map[int]bool), false}
+
+	// Walking more code finds more writes, which may make more jumps unknown
+	for {
+		listing.instructions = make(map[int]Instruction)
+		listing.labels = make(map[int]string)
+		listing.swept = false
+		listing.walk(0)
+
+		var count int = len(listing.written)
+		for address := range listing.writes() {
+			listing.written[address] = true
+		}
+		if len(listing.written) == count {
+			break
+		}
+	}
+
+	if listing.swept {
+		listing.sweep()
+	}
+
+	// Targets inside another instruction have no line to be labelled on
+	for _, instruction := range listing.instructions {
+		for address := instruction.Address + 1; address < instruction.Address+instruction.Opcode.Size(); address++ {
+			delete(listing.labels, address)
+		}
+	}
+
+	return listing
+}
+
+// walk lists every instruction reachable from the address, marking the
+// listing to be swept when some of them cannot be followed.
+func (listing *Listing) walk(address int) {
+	var codes []int = listing.codes
+
+	var pending []int = []int{address}
+	for len(pending) != 0 {
+		var address int = pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		for address >= 0 && address < len(codes) {
+			if _, is_set := listing.instructions[address]; is_set {
+				break
+			}
+			// Code rewritten before it runs is some other instruction
+			instruction, valid := decode_instruction(codes, address)
+			if !valid {
+				listing.swept = listing.swept || listing.written[address]
+				break
+			}
+			listing.instructions[address] = instruction
+
+			targets, falls_through, known := instruction.successors(listing.written)
+			listing.swept = listing.swept || !known
+			for _, target := range targets {
+				listing.label(target)
+				pending = append(pending, target)
+			}
+
+			if return_address, is_call := pushed_return_address(codes, instruction, listing.written); is_call && return_address >= 0 && return_address < len(codes) {
+				listing.label(return_address)
+				pending = append(pending, return_address)
+			}
+
+			if !falls_through {
+				break
+			}
+			address = address + instruction.Opcode.Size()
+		}
+	}
+}
+
+// sweep decodes every cell the walk left over, in address order, skipping
+// the ones that are no instruction. Instructions followed by something that
+// cannot be one are taken as out of step and skipped too.
+func (listing *Listing) sweep() {
+	var address int = 0
+	for address < len(listing.codes) {
+		if instruction, is_set := listing.instructions[address]; is_set {
+			address = address + instruction.Opcode.Size()
+			continue
+		}
+
+		instruction, valid := decode_instruction(listing.codes, address)
+		for offset := 1; valid && offset < instruction.Opcode.Size(); offset++ {
+			valid = !listing.IsCode(address + offset)
+		}
+		if next := address + instruction.Opcode.Size(); valid && next < len(listing.codes) && !listing.IsCode(next) {
+			_, valid = decode_instruction(listing.codes, next)
+		}
+		if !valid {
+			address = address + 1
+			continue
+		}
+
+		listing.instructions[address] = instruction
+		targets, _, _ := instruction.successors(listing.written)
+		for _, target := range targets {
+			listing.label(target)
+		}
+		address = address + instruction.Opcode.Size()
+	}
+}
+
+func (listing *Listing) label(address int) {
+	if address >= 0 && address < len(listing.codes) {
+		listing.labels[address] = fmt.Sprintf("L%04d", address)
+	}
+}
+
+// writes gives the cells the listed instructions write to in position mode.
+func (listing *Listing) writes() map[int]bool {
+	var written map[int]bool = make(map[int]bool)
+	for _, instruction := range listing.instructions {
+		number_arg, writing_args := arguments_of(instruction.Opcode.Code)
+		for arg_index := number_arg - writing_args; arg_index < number_arg; arg_index++ {
+			if instruction.Opcode.Tag(arg_index) == 0 {
+				written[instruction.Arguments[arg_index]] = true
+			}
+		}
+	}
+
+	return written
+}
+
+func decode_instruction(codes []int, address int) (Instruction, bool) {
+	opcode, err := GetOpcode(codes[address])
+	if err != nil {
+		return Instruction{}, false
+	}
+
+	var size int = opcode.Size()
+	if address+size > len(codes) {
+		return Instruction{}, false
+	}
+
+	// Writing in immediate mode makes no sense as code
+	number_arg, writing_args := arguments_of(opcode.Code)
+	for arg_index := number_arg - writing_args; arg_index < number_arg; arg_index++ {
+		if opcode.Tag(arg_index) == 1 {
+			return Instruction{}, false
+		}
+	}
+	// Neither do modes past the arguments, which could not be listed
+	for arg_index := number_arg; arg_index < len(opcode.Tags); arg_index++ {
+		if opcode.Tags[arg_index] != 0 {
+			return Instruction{}, false
+		}
+	}
+
+	return Instruction{address, opcode, codes[address+1 : address+size]}, true
+}
+
+// successors gives the immediate jump targets of the instruction, whether
+// execution can continue on the next instruction and whether every target
+// is known. Returns through rb+0 count as known, as they go back to a
+// return address found at the call. Arguments in written cells are not
+// trusted.
+func (instruction Instruction) successors(written map[int]bool) ([]int, bool, bool) {
+	switch instruction.Opcode.Code {
+	case 99:
+		return nil, false, true
+
+	case 5, 6:
+		var targets []int = make([]int, 0, 1)
+		var known bool = true
+		switch {
+		case instruction.Opcode.Tag(1) == 1 && !written[instruction.Address+2]:
+			targets = append(targets, instruction.Arguments[1])
+		case instruction.Opcode.Tag(1) == 2 && instruction.Arguments[1] == 0:
+		default:
+			known = false
+		}
+
+		// Immediate conditions always or never jump, unless rewritten
+		if instruction.Opcode.Tag(0) == 1 && !written[instruction.Address+1] {
+			var jumps bool = (instruction.Arguments[0] != 0) == (instruction.Opcode.Code == 5)
+			if jumps {
+				return targets, false, known
+			}
+			return nil, true, true
+		}
+		return targets, true, known
+
+	default:
+		return nil, true, true
+	}
+}
+
+// pushed_return_address recognizes the call idiom: an immediate value stored
+// at rb+0 right before an unconditional jump.
+func pushed_return_address(codes []int, instruction Instruction, written map[int]bool) (int, bool) {
+	var code int = instruction.Opcode.Code
+	if code != 1 && code != 2 {
+		return 0, false
+	}
+	if instruction.Opcode.Tag(0) != 1 || instruction.Opcode.Tag(1) != 1 || instruction.Opcode.Tag(2) != 2 || instruction.Arguments[2] != 0 {
+		return 0, false
+	}
+
+	var next_address int = instruction.Address + instruction.Opcode.Size()
+	if next_address >= len(codes) {
+		return 0, false
+	}
+	next, valid := decode_instruction(codes, next_address)
+	if !valid {
+		return 0, false
+	}
+	if _, falls_through, _ := next.successors(written); falls_through || next.Opcode.Code == 99 {
+		return 0, false
+	}
+
+	if code == 1 {
+		return instruction.Arguments[0] + instruction.Arguments[1], true
+	}
+	return instruction.Arguments[0] * instruction.Arguments[1], true
+}
+
+// IsCode tells whether the address holds the start of a reachable instruction.
+func (listing Listing) IsCode(address int) bool {
+	_, is_set := listing.instructions[address]
+	return is_set
+}
+
+// Instructions returns the reachable instructions in address order.
+func (listing Listing) Instructions() []Instruction {
+	var instructions []Instruction = make([]Instruction, 0, len(listing.instructions))
+	for _, instruction := range listing.instructions {
+		instructions = append(instructions, instruction)
+	}
+	sort.Slice(instructions, func(i, j int) bool { return instructions[i].Address < instructions[j].Address })
+
+	return instructions
+}
+
+// Label returns the label of a jump target, if it is one.
+func (listing Listing) Label(address int) (string, bool) {
+	label, is_set := listing.labels[address]
+	return label, is_set
+}
+
+func (instruction Instruction) format_argument(arg_index int, labels map[int]string) string {
+	var value int = instruction.Arguments[arg_index]
+	var code int = instruction.Opcode.Code
+
+	switch instruction.Opcode.Tag(arg_index) {
+	case 1:
+		// Jump targets read better as labels
+		if (code == 5 || code == 6) && arg_index == 1 {
+			if label, is_set := labels[value]; is_set {
+				return label
+			}
+		}
+		return fmt.Sprintf("#%d", value)
+	case 2:
+		if value < 0 {
+			return fmt.Sprintf("rb%d", value)
+		}
+		return fmt.Sprintf("rb+%d", value)
+	default:
+		return fmt.Sprintf("[%d]", value)
+	}
+}
+
+func (instruction Instruction) format(labels map[int]string) string {
+	var arguments []string = make([]string, 0, len(instruction.Arguments))
+	for arg_index := range instruction.Arguments {
+		arguments = append(arguments, instruction.format_argument(arg_index, labels))
+	}
+
+	var line string = fmt.Sprintf("%-4s %s", Mnemonics[instruction.Opcode.Code], strings.Join(arguments, ", "))
+	return strings.TrimRight(line, " ")
+}
+
+// String gives the instruction in assembly syntax, such as "ADD [4], #3, rb+1".
+func (instruction Instruction) String() string {
+	return instruction.format(nil)
+}
+
+// Print writes the annotated listing: one line per instruction and up to
+// eight values per line of data, jump targets labelled.
+func (listing Listing) Print(writer io.Writer) {
+	const DATA_PER_LINE int = 8
+
+	var address int = 0
+	for address < len(listing.codes) {
+		var label string = listing.labels[address]
+		if label != "" {
+			label = label + ":"
+		}
+
+		instruction, is_code := listing.instructions[address]
+		if is_code {
+			fmt.Fprintf(writer, "%05d  %-7s %s\n", address, label, instruction.format(listing.labels))
+			address = address + instruction.Opcode.Size()
+			continue
+		}
+
+		// Gather data until the next instruction or label
+		var values []string = make([]string, 0, DATA_PER_LINE)
+		var data_address int = address
+		for data_address < len(listing.codes) && len(values) < DATA_PER_LINE {
+			if listing.IsCode(data_address) {
+				break
+			}
+			if _, is_label := listing.labels[data_address]; is_label && data_address != address {
+				break
+			}
+
+			values = append(values, fmt.Sprintf("%d", listing.codes[data_address]))
+			data_address = data_address + 1
+		}
+
+		fmt.Fprintf(writer, "%05d  %-7s %-4s %s\n", address, label, "DATA", strings.Join(values, ", "))
+		address = data_address
+	}
+}
+
+// ----------------------- Disassembler Struct End -----------------------
@@ -0,0 +1,80 @@
+package intcode
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Listings are compared against the ones in testdata, written with
+//
+//	go run ./intcode/cmd/disassemble day_09/input.txt > intcode/testdata/day_09.lst
+func TestDisassembleGolden(t *testing.T) {
+	for _, day := range []string{"day_02", "day_09"} {
+		t.Run(day, func(t *testing.T) {
+			content, err := os.ReadFile("../" + day + "/input.txt")
+			if err != nil {
+				t.Skip(err)
+			}
+			codes, err := Parse(string(content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, err := os.ReadFile("testdata/" + day + ".lst")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var listing bytes.Buffer
+			Disassemble(codes).Print(&listing)
+			var lines []string = strings.Split(listing.String(), "\n")
+			var expected_lines []string = strings.Split(string(expected), "\n")
+			for index := 0; index < len(lines) && index < len(expected_lines); index++ {
+				if lines[index] != expected_lines[index] {
+					t.Fatalf("line %d is ' %s ', expected ' %s '", index+1, lines[index], expected_lines[index])
+				}
+			}
+			if len(lines) != len(expected_lines) {
+				t.Fatalf("%d lines, expected %d", len(lines), len(expected_lines))
+			}
+		})
+	}
+}
+
+func TestDisassembleCalls(t *testing.T) {
+	// Pushes the return address 7 and jumps to the function at 9
+	var codes []int = []int{21101, 7, 0, 0, 1105, 1, 9, 4, 0, 99}
+	var listing Listing = Disassemble(codes)
+
+	var addresses []int = make([]int, 0)
+	for _, instruction := range listing.Instructions() {
+		addresses = append(addresses, instruction.Address)
+	}
+	if !reflect.DeepEqual(addresses, []int{0, 4, 7, 9}) {
+		t.Fatalf("instructions at %v, expected [0 4 7 9]", addresses)
+	}
+	if label, _ := listing.Label(7); label != "L0007" {
+		t.Fatalf("return address labelled ' %s '", label)
+	}
+
+	// The same sum with no jump after it is no call
+	listing = Disassemble([]int{21101, 7, 0, 0, 99, 0, 0, 104, 0})
+	if listing.IsCode(7) {
+		t.Fatalf("sum taken as a return address")
+	}
+}
+
+func TestDisassembleRewrittenJump(t *testing.T) {
+	// Reads the jump target at 4, so the code past the jump is only swept
+	var listing Listing = Disassemble([]int{3, 4, 1105, 1, -1, 104, 1, 99})
+
+	var addresses []int = make([]int, 0)
+	for _, instruction := range listing.Instructions() {
+		addresses = append(addresses, instruction.Address)
+	}
+	if !reflect.DeepEqual(addresses, []int{0, 2, 5, 7}) {
+		t.Fatalf("instructions at %v, expected [0 2 5 7]", addresses)
+	}
+}
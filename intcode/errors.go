@@ -0,0 +1,57 @@
+package intcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ----------------------- Errors Start -----------------------
+
+// Kinds of failure an IntCode program can run into, to be matched with
+// errors.Is against anything returned by the computer.
+var (
+	ErrUnknownOpcode        = errors.New("opcode value not recognized")
+	ErrInvalidParameterMode = errors.New("tag value not recognized")
+	ErrWriteImmediateMode   = errors.New("writing argument in immediate mode")
+	ErrNegativeAddress      = errors.New("negative memory address")
+	ErrOverflow             = errors.New("arithmetic overflow")
+	ErrAddressOutOfRange    = errors.New("memory address out of range")
+)
+
+// Failure of symbolic execution to follow a program.
+var ErrSymbolicControl = errors.New("control depends on symbols")
+
+// Failures reading a snapshot back.
+var (
+	ErrSnapshotVersion = errors.New("snapshot version not supported")
+	ErrSnapshotInvalid = errors.New("snapshot is not consistent")
+)
+
+// Failure reading a recorded session back.
+var ErrSessionInvalid = errors.New("session is not valid")
+
+// Failure reading a binary trace back.
+var ErrTraceInvalid = errors.New("trace is not valid")
+
+// Failure to run a network with no NAT to stop it.
+var ErrNoNAT = errors.New("network has no NAT")
+
+// Failure reading a packet capture back.
+var ErrCaptureInvalid = errors.New("capture is not valid")
+
+// InstructionError locates a failure at the instruction that caused it.
+type InstructionError struct {
+	Err         error
+	Pointer     int
+	Instruction int
+}
+
+func (err *InstructionError) Error() string {
+	return fmt.Sprintf("%v at ' %d ' (instruction ' %d ')", err.Err, err.Pointer, err.Instruction)
+}
+
+func (err *InstructionError) Unwrap() error {
+	return err.Err
+}
+
+// ----------------------- Errors End -----------------------
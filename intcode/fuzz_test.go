@@ -0,0 +1,204 @@
+package intcode
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// Instructions a fuzzed program may execute before it is stopped
+const FUZZ_BUDGET int = 10000
+
+// Every error the computer is allowed to return
+var vm_error_kinds []error = []error{
+	ErrUnknownOpcode,
+	ErrInvalidParameterMode,
+	ErrWriteImmediateMode,
+	ErrNegativeAddress,
+	ErrOverflow,
+	ErrAddressOutOfRange,
+}
+
+// fuzz_values reads the data as little endian 16 bit values, which reach every
+// opcode with every parameter mode while keeping addresses small.
+func fuzz_values(data []byte) []int {
+	var values []int = make([]int, 0, len(data)/2)
+	for index := 0; index+1 < len(data); index = index + 2 {
+		values = append(values, int(int16(binary.LittleEndian.Uint16(data[index:]))))
+	}
+	return values
+}
+
+func fuzz_bytes(values []int) []byte {
+	var data []byte = make([]byte, 2*len(values))
+	for index, value := range values {
+		binary.LittleEndian.PutUint16(data[2*index:], uint16(int16(value)))
+	}
+	return data
+}
+
+func add_fuzz_seeds(f *testing.F) {
+	for _, test := range conformance_cases {
+		codes, err := Parse(test.program)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(fuzz_bytes(codes), fuzz_bytes(test.input))
+	}
+	f.Add(fuzz_bytes(FAR_RELATIVE_WRITE), []byte{})
+	f.Add([]byte{}, []byte{})
+}
+
+func check_error_kind(t *testing.T, err error) {
+	if err == nil {
+		return
+	}
+
+	var instruction_error *InstructionError
+	if !errors.As(err, &instruction_error) {
+		t.Fatalf("error ' %v ' does not locate its instruction", err)
+	}
+	for _, kind := range vm_error_kinds {
+		if errors.Is(err, kind) {
+			return
+		}
+	}
+	t.Fatalf("error ' %v ' is not one of the defined kinds", err)
+}
+
+// fuzz_outcome is everything observable about a computer after a run.
+type fuzz_outcome struct {
+	state             State
+	pointer           int
+	relative_base     int
+	instruction_count int
+	memory_size       int
+	memory            map[int]int
+	output            []int
+	err               string
+}
+
+func outcome_of(computer *IntCodeComputer, err error) fuzz_outcome {
+	var message string = ""
+	if err != nil {
+		message = err.Error()
+	}
+
+	return fuzz_outcome{computer.State(), computer.Pointer(), computer.RelativeBase(), computer.InstructionCount(),
+		computer.memory.size, computer.memory.written(), append([]int{}, computer.Output()...), message}
+}
+
+// FuzzComputer runs random programs on random input, checking the computer
+// never panics, only fails with its own errors, and that copies made before
+// and halfway through the run end exactly like the original. Dense memory
+// is bounded by MAX_DENSE_ADDRESS, so far writes fail instead of growing it.
+//
+// Runs minimizing a new input are not counted, so the fuzzer may report 0
+// execs/sec for a while; -fuzzminimizetime 0 skips minimizing.
+func FuzzComputer(f *testing.F) {
+	add_fuzz_seeds(f)
+
+	f.Fuzz(func(t *testing.T, image []byte, input []byte) {
+		for _, model := range []MemoryModel{DenseMemory, SparseMemory} {
+			var computer IntCodeComputer = NewFromCodesWithMemory(fuzz_values(image), model)
+			computer.AddInput(fuzz_values(input)...)
+			var before IntCodeComputer = MakeDeepCopy(computer)
+
+			_, err := computer.RunFor(FUZZ_BUDGET / 2)
+			check_error_kind(t, err)
+			var halfway IntCodeComputer = MakeDeepCopy(computer)
+
+			_, err = computer.RunFor(FUZZ_BUDGET / 2)
+			check_error_kind(t, err)
+			var expected fuzz_outcome = outcome_of(&computer, err)
+
+			_, err = halfway.RunFor(FUZZ_BUDGET / 2)
+			if outcome := outcome_of(&halfway, err); !reflect.DeepEqual(outcome, expected) {
+				t.Fatalf("%v: copy made halfway ended %+v, expected %+v", model, outcome, expected)
+			}
+
+			before.RunFor(FUZZ_BUDGET / 2)
+			_, err = before.RunFor(FUZZ_BUDGET / 2)
+			if outcome := outcome_of(&before, err); !reflect.DeepEqual(outcome, expected) {
+				t.Fatalf("%v: copy made before running ended %+v, expected %+v", model, outcome, expected)
+			}
+		}
+	})
+}
+
+// fuzz_sink collects output written through SetOutput.
+type fuzz_sink struct {
+	values []int
+}
+
+func (sink *fuzz_sink) Write(value int) {
+	sink.values = append(sink.values, value)
+}
+
+// FuzzHostAdapters checks that feeding input through a channel and taking
+// output through a sink behaves like the computer's own queues.
+func FuzzHostAdapters(f *testing.F) {
+	add_fuzz_seeds(f)
+
+	f.Fuzz(func(t *testing.T, image []byte, input []byte) {
+		var codes []int = fuzz_values(image)
+		var values []int = fuzz_values(input)
+
+		var queued IntCodeComputer = NewFromCodes(codes)
+		queued.AddInput(values...)
+		queued_count, queued_err := queued.RunFor(FUZZ_BUDGET)
+		check_error_kind(t, queued_err)
+
+		var channel chan int = make(chan int, len(values))
+		for _, value := range values {
+			channel <- value
+		}
+		close(channel)
+
+		var hosted IntCodeComputer = NewFromCodes(codes)
+		var sink *fuzz_sink = &fuzz_sink{make([]int, 0)}
+		hosted.SetInput(NewChannelInput(channel))
+		hosted.SetOutput(sink)
+		hosted_count, hosted_err := hosted.RunFor(FUZZ_BUDGET)
+		check_error_kind(t, hosted_err)
+
+		if hosted_count != queued_count || hosted.State() != queued.State() || hosted.Pointer() != queued.Pointer() {
+			t.Fatalf("hosted stopped ' %v ' at %d after %d, queued ' %v ' at %d after %d",
+				hosted.State(), hosted.Pointer(), hosted_count, queued.State(), queued.Pointer(), queued_count)
+		}
+		if !reflect.DeepEqual(sink.values, queued.Output()) {
+			t.Fatalf("hosted output %v, queued output %v", sink.values, queued.Output())
+		}
+		if (hosted_err == nil) != (queued_err == nil) || (hosted_err != nil && hosted_err.Error() != queued_err.Error()) {
+			t.Fatalf("hosted failed with ' %v ', queued with ' %v '", hosted_err, queued_err)
+		}
+	})
+}
+
+// FuzzBigComputer checks the arbitrary precision computer never panics and
+// only fails with the defined errors.
+func FuzzBigComputer(f *testing.F) {
+	add_fuzz_seeds(f)
+
+	f.Fuzz(func(t *testing.T, image []byte, input []byte) {
+		var codes []*big.Int = make([]*big.Int, 0)
+		for _, value := range fuzz_values(image) {
+			codes = append(codes, big.NewInt(int64(value)))
+		}
+
+		var computer BigComputer = NewBigFromCodes(codes)
+		for _, value := range fuzz_values(input) {
+			computer.AddInput(big.NewInt(int64(value)))
+		}
+
+		for executed := 0; executed < FUZZ_BUDGET && computer.State() != Halted && computer.State() != AwaitingInput; executed++ {
+			err := computer.Step()
+			if err != nil {
+				check_error_kind(t, err)
+				return
+			}
+		}
+	})
+}
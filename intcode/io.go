@@ -0,0 +1,97 @@
+package intcode
+
+// ----------------------- Input / Output Start -----------------------
+
+// InputSource feeds input instructions. Read reports false when there is no
+// value to give, which leaves the computer awaiting input.
+type InputSource interface {
+	Read() (int, bool)
+}
+
+// OutputSink receives every value written by output instructions.
+type OutputSink interface {
+	Write(value int)
+}
+
+// ChannelInput reads input from a channel, blocking until a value arrives.
+// Once the channel is closed the computer is left awaiting input.
+type ChannelInput struct {
+	channel <-chan int
+}
+
+func NewChannelInput(channel <-chan int) *ChannelInput {
+	return &ChannelInput{channel}
+}
+
+func (input *ChannelInput) Read() (int, bool) {
+	value, open := <-input.channel
+	return value, open
+}
+
+// ChannelOutput sends every output value on a channel.
+type ChannelOutput struct {
+	channel chan<- int
+}
+
+func NewChannelOutput(channel chan<- int) *ChannelOutput {
+	return &ChannelOutput{channel}
+}
+
+func (output *ChannelOutput) Write(value int) {
+	output.channel <- value
+}
+
+// SetInput makes input instructions read from source instead of the values
+// queued with AddInput.
+func (computer *IntCodeComputer) SetInput(source InputSource) {
+	computer.input_source = source
+}
+
+// SetOutput makes output instructions write to sink instead of the values
+// returned by Output.
+func (computer *IntCodeComputer) SetOutput(sink OutputSink) {
+	computer.output_sink = sink
+}
+
+// RunWithChannels runs the computer as a goroutine would, reading input from
+// one channel and writing output to another. The output channel is closed when
+// the computer stops, so whoever reads it knows no more values will follow.
+func (computer *IntCodeComputer) RunWithChannels(input <-chan int, output chan<- int) error {
+	defer close(output)
+
+	computer.SetInput(NewChannelInput(input))
+	computer.SetOutput(NewChannelOutput(output))
+	return computer.Run()
+}
+
+func (computer *IntCodeComputer) read_input() (int, bool) {
+	var value int = 0
+	var available bool = false
+	if computer.input_source != nil {
+		value, available = computer.input_source.Read()
+	} else if computer.input_pointer < len(computer.input) {
+		value, available = computer.input[computer.input_pointer], true
+		computer.input_pointer = computer.input_pointer + 1
+	}
+
+	if available && computer.session != nil {
+		computer.session.record(SESSION_INPUT, value, computer.instruction_count)
+	}
+	return value, available
+}
+
+func (computer *IntCodeComputer) write_output(value int) {
+	if computer.session != nil {
+		computer.session.record(SESSION_OUTPUT, value, computer.instruction_count)
+	}
+
+	computer.output_count = computer.output_count + 1
+	if computer.output_sink != nil {
+		computer.output_sink.Write(value)
+		return
+	}
+
+	computer.output = append(computer.output, value)
+}
+
+// ----------------------- Input / Output End -----------------------
@@ -0,0 +1,204 @@
+package intcode
+
+import (
+	"fmt"
+	"math"
+)
+
+// ----------------------- Paged Memory Struct Start -----------------------
+
+const PAGE_SIZE int = 512
+
+// MAX_DENSE_ADDRESS is the highest address dense memory reaches, far past
+// what puzzle programs use but keeping its page slots and flat snapshots
+// small. Programs going further need SparseMemory.
+const MAX_DENSE_ADDRESS int = 1<<20 - 1
+
+// MemoryModel chooses how a computer stores its memory.
+type MemoryModel int
+
+const (
+	// DenseMemory keeps a page slot for every page up to the highest address,
+	// which is fastest for programs staying close to their image. It stops at
+	// MAX_DENSE_ADDRESS.
+	DenseMemory MemoryModel = iota
+	// SparseMemory only keeps the pages written to, so far addresses cost
+	// no more than near ones.
+	SparseMemory
+)
+
+var memory_model_names map[MemoryModel]string = map[MemoryModel]string{
+	DenseMemory:  "dense",
+	SparseMemory: "sparse",
+}
+
+func (model MemoryModel) String() string {
+	name, is_set := memory_model_names[model]
+	if !is_set {
+		return fmt.Sprintf("MemoryModel(%d)", int(model))
+	}
+	return name
+}
+
+type memory_page struct {
+	cells  [PAGE_SIZE]int
+	frozen bool
+}
+
+// paged_memory splits memory into pages shared between clones. A clone
+// freezes every page, and whoever writes to a frozen page first gets its own
+// copy, so forking a computer costs one pointer per page instead of a cell
+// copy. Missing pages read as the default value. Sparse memory keeps its
+// pages in a map instead of a slice.
+type paged_memory struct {
+	pages         []*memory_page
+	sparse        map[int]*memory_page
+	size          int
+	default_value int
+}
+
+func new_paged_memory(codes []int, default_value int, model MemoryModel) paged_memory {
+	var memory paged_memory = paged_memory{make([]*memory_page, 0, len(codes)/PAGE_SIZE+1), nil, 0, default_value}
+	if model == SparseMemory {
+		memory.sparse = make(map[int]*memory_page)
+	}
+	for address, value := range codes {
+		memory.set(address, value)
+	}
+	memory.size = len(codes)
+
+	return memory
+}
+
+// grow makes the address part of memory, as the puzzle treats memory as
+// available past the program. Dense memory fails past MAX_DENSE_ADDRESS, and
+// no memory reaches the largest int, as its size would not fit.
+func (memory *paged_memory) grow(address int) error {
+	if address < memory.size {
+		return nil
+	}
+	if address == math.MaxInt || (memory.sparse == nil && address > MAX_DENSE_ADDRESS) {
+		return fmt.Errorf("%w: ' %d ' in %v memory", ErrAddressOutOfRange, address, memory.model())
+	}
+
+	memory.size = address + 1
+	return nil
+}
+
+// get reads a cell without growing memory.
+func (memory *paged_memory) get(address int) int {
+	var page_index int = address / PAGE_SIZE
+	var page *memory_page = nil
+	if memory.sparse != nil {
+		page = memory.sparse[page_index]
+	} else if page_index < len(memory.pages) {
+		page = memory.pages[page_index]
+	}
+
+	if page == nil {
+		return memory.default_value
+	}
+	return page.cells[address%PAGE_SIZE]
+}
+
+func (memory *paged_memory) model() MemoryModel {
+	if memory.sparse != nil {
+		return SparseMemory
+	}
+	return DenseMemory
+}
+
+func (memory *paged_memory) set(address int, value int) {
+	var page_index int = address / PAGE_SIZE
+	var page *memory_page = nil
+	if memory.sparse != nil {
+		page = memory.sparse[page_index]
+	} else {
+		for page_index >= len(memory.pages) {
+			memory.pages = append(memory.pages, nil)
+		}
+		page = memory.pages[page_index]
+	}
+
+	if page == nil {
+		page = &memory_page{}
+		if memory.default_value != 0 {
+			for index := range page.cells {
+				page.cells[index] = memory.default_value
+			}
+		}
+		memory.store_page(page_index, page)
+	} else if page.frozen {
+		page = &memory_page{page.cells, false}
+		memory.store_page(page_index, page)
+	}
+
+	page.cells[address%PAGE_SIZE] = value
+}
+
+func (memory *paged_memory) store_page(page_index int, page *memory_page) {
+	if memory.sparse != nil {
+		memory.sparse[page_index] = page
+	} else {
+		memory.pages[page_index] = page
+	}
+}
+
+// clone shares every page with the copy until either side writes to it.
+func (memory *paged_memory) clone() paged_memory {
+	for _, page := range memory.pages {
+		if page != nil {
+			page.frozen = true
+		}
+	}
+
+	var pages []*memory_page = make([]*memory_page, len(memory.pages))
+	copy(pages, memory.pages)
+
+	var sparse map[int]*memory_page = nil
+	if memory.sparse != nil {
+		sparse = make(map[int]*memory_page, len(memory.sparse))
+		for page_index, page := range memory.sparse {
+			page.frozen = true
+			sparse[page_index] = page
+		}
+	}
+
+	return paged_memory{pages, sparse, memory.size, memory.default_value}
+}
+
+// written returns every cell of the pages in use that differs from the
+// default value, which is all a sparse memory needs to be rebuilt.
+func (memory *paged_memory) written() map[int]int {
+	var cells map[int]int = make(map[int]int)
+	var collect = func(page_index int, page *memory_page) {
+		for index, value := range page.cells {
+			if value != memory.default_value {
+				cells[page_index*PAGE_SIZE+index] = value
+			}
+		}
+	}
+
+	for page_index, page := range memory.pages {
+		if page != nil {
+			collect(page_index, page)
+		}
+	}
+	for page_index, page := range memory.sparse {
+		collect(page_index, page)
+	}
+
+	return cells
+}
+
+// cells returns a flat copy of memory.
+func (memory *paged_memory) cells() []int {
+	var cells []int = make([]int, memory.size)
+	for address := range cells {
+		cells[address] = memory.get(address)
+	}
+
+	return cells
+}
+
+// ----------------------- Paged Memory Struct End -----------------------
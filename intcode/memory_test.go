@@ -0,0 +1,197 @@
+package intcode
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func heap_in_use() uint64 {
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	return stats.HeapInuse
+}
+
+func TestSparseFarWrite(t *testing.T) {
+	// Stores 7 a trillion cells away, then outputs it back
+	var computer IntCodeComputer = NewFromCodesWithMemory([]int{1101, 7, 0, 1000000000000, 4, 1000000000000, 99}, SparseMemory)
+
+	var before uint64 = heap_in_use()
+	err := computer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var after uint64 = heap_in_use()
+
+	if output := computer.Output(); len(output) != 1 || output[0] != 7 {
+		t.Fatalf("output %v, expected [7]", output)
+	}
+	if after > before && after-before > 1<<20 {
+		t.Fatalf("heap grew by %d bytes for a single far write", after-before)
+	}
+}
+
+func TestSparseHugeAddresses(t *testing.T) {
+	var computer IntCodeComputer = NewFromCodesWithMemory([]int{99}, SparseMemory)
+
+	for _, address := range []int{1 << 40, 1 << 62, 1<<62 + 1} {
+		err := computer.WriteMemory(address, address/3)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, address := range []int{1 << 40, 1 << 62, 1<<62 + 1} {
+		value, err := computer.ReadMemory(address)
+		if err != nil || value != address/3 {
+			t.Fatalf("read %d at ' %d ' (%v), expected %d", value, address, err, address/3)
+		}
+	}
+	if value, _ := computer.ReadMemory(1<<62 - 1); value != 0 {
+		t.Fatalf("unwritten cell holds %d", value)
+	}
+}
+
+func TestSparseNegativeAddress(t *testing.T) {
+	var computer IntCodeComputer = NewFromCodesWithMemory([]int{1101, 1, 1, -5, 99}, SparseMemory)
+
+	err := computer.Run()
+	if !errors.Is(err, ErrNegativeAddress) {
+		t.Fatalf("expected a negative address error, got %v", err)
+	}
+	if _, err := computer.ReadMemory(-1); !errors.Is(err, ErrNegativeAddress) {
+		t.Fatalf("expected a negative address error, got %v", err)
+	}
+	if err := computer.WriteMemory(-1, 0); !errors.Is(err, ErrNegativeAddress) {
+		t.Fatalf("expected a negative address error, got %v", err)
+	}
+}
+
+// Squares 32767 twice into the relative base and writes there
+var FAR_RELATIVE_WRITE []int = []int{1102, 32767, 32767, 20, 2, 20, 20, 20, 9, 20, 21101, 1, 1, 0, 99, 0, 0, 0, 0, 0, 0}
+
+func TestDenseFarWrite(t *testing.T) {
+	var computer IntCodeComputer = NewFromCodes(FAR_RELATIVE_WRITE)
+
+	var before uint64 = heap_in_use()
+	err := computer.Run()
+	var after uint64 = heap_in_use()
+
+	var instruction_error *InstructionError
+	if !errors.Is(err, ErrAddressOutOfRange) || !errors.As(err, &instruction_error) || instruction_error.Pointer != 10 {
+		t.Fatalf("got ' %v ', expected an out of range write at ' 10 '", err)
+	}
+	if computer.State() != Faulted {
+		t.Fatalf("computer left ' %v '", computer.State())
+	}
+	if after > before && after-before > 1<<20 {
+		t.Fatalf("heap grew by %d bytes for a failed far write", after-before)
+	}
+
+	// The same program has room in sparse memory
+	computer = NewFromCodesWithMemory(FAR_RELATIVE_WRITE, SparseMemory)
+	err = computer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemoryLimits(t *testing.T) {
+	var dense IntCodeComputer = NewFromCodes([]int{99})
+	if err := dense.WriteMemory(MAX_DENSE_ADDRESS, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dense.ReadMemory(MAX_DENSE_ADDRESS + 1); !errors.Is(err, ErrAddressOutOfRange) {
+		t.Fatalf("read past dense memory with ' %v '", err)
+	}
+	if snapshot := dense.Snapshot(); len(snapshot.Memory) != MAX_DENSE_ADDRESS+1 {
+		t.Fatalf("snapshot of %d cells, expected %d", len(snapshot.Memory), MAX_DENSE_ADDRESS+1)
+	}
+
+	// A size past the largest address would wrap around
+	var sparse IntCodeComputer = NewFromCodesWithMemory([]int{99}, SparseMemory)
+	if err := sparse.WriteMemory(math.MaxInt, 1); !errors.Is(err, ErrAddressOutOfRange) {
+		t.Fatalf("wrote the largest address with ' %v '", err)
+	}
+	if err := sparse.WriteMemory(math.MaxInt-1, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSparseCloneAndSnapshot(t *testing.T) {
+	var computer IntCodeComputer = NewFromCodesWithMemory([]int{99}, SparseMemory)
+	computer.WriteMemory(1<<50, 1)
+
+	var clone IntCodeComputer = MakeDeepCopy(computer)
+	clone.WriteMemory(1<<50, 2)
+	if value, _ := computer.ReadMemory(1 << 50); value != 1 {
+		t.Fatalf("clone write leaked into the original: %d", value)
+	}
+
+	var buffer bytes.Buffer
+	err := clone.Save(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := Load(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := restored.ReadMemory(1 << 50); value != 2 || restored.memory.model() != SparseMemory {
+		t.Fatalf("restored %d with %v memory", value, restored.memory.model())
+	}
+}
+
+func load_day(b testing.TB, day string) IntCodeComputer {
+	content, err := os.ReadFile("../" + day + "/input.txt")
+	if err != nil {
+		b.Skip(err)
+	}
+
+	computer, err := New(string(content))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return computer
+}
+
+// The fork benchmarks only use the exported API, so the same code measures
+// the flat memory computer from before paging.
+
+// Forks the beam computer of day 19 once per probed position.
+func BenchmarkForkDay19(b *testing.B) {
+	var drone IntCodeComputer = load_day(b, "day_19")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		var probe IntCodeComputer = MakeDeepCopy(drone)
+		probe.AddInput(iteration%50, iteration/50%50)
+		err := probe.Run()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "forks/s")
+}
+
+// Forks the repair droid of day 15 once per explored direction.
+func BenchmarkForkDay15(b *testing.B) {
+	var droid IntCodeComputer = load_day(b, "day_15")
+	droid.Run()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for iteration := 0; iteration < b.N; iteration++ {
+		var subdroid IntCodeComputer = MakeDeepCopy(droid)
+		subdroid.AddInput(iteration%4 + 1)
+		err := subdroid.Run()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "forks/s")
+}
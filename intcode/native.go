@@ -0,0 +1,145 @@
+package intcode
+
+// ----------------------- Compiled Program Struct Start -----------------------
+
+// CompiledProgram is a program image translated to Go by Compile. Its run
+// function executes compiled instructions from ip for as long as it can and
+// returns the address of the first instruction it leaves to the interpreter:
+// one it did not compile, one whose cells were written since, or one that
+// has to halt, wait for input or fail.
+type CompiledProgram struct {
+	image []int
+	sizes []int
+	code  []bool
+	run   func(native *Native, ip int) int
+}
+
+// NewCompiledProgram is called by generated code with the image it was
+// compiled from and the addresses of the instructions it compiled.
+func NewCompiledProgram(image []int, addresses []int, run func(native *Native, ip int) int) *CompiledProgram {
+	var sizes []int = make([]int, len(image))
+	var code []bool = make([]bool, len(image))
+	for _, address := range addresses {
+		opcode, _ := GetOpcode(image[address])
+		sizes[address] = opcode.Size()
+		for index := 0; index < opcode.Size(); index++ {
+			code[address+index] = true
+		}
+	}
+
+	return &CompiledProgram{image, sizes, code, run}
+}
+
+// Native is what compiled code sees of the computer it runs on.
+type Native struct {
+	RelativeBase int
+	Count        int
+	computer     *IntCodeComputer
+	program      *CompiledProgram
+	dirty        map[int]bool
+}
+
+// Read and Write are only given addresses compiled code checked are within
+// dense memory, which every memory model can grow to.
+func (native *Native) Read(address int) int {
+	native.computer.memory.grow(address)
+	return native.computer.memory.get(address)
+}
+
+// Write stores the value and tells whether it changed compiled code, in
+// which case the compiled function has to leave.
+func (native *Native) Write(address int, value int) bool {
+	native.computer.memory.grow(address)
+	native.computer.memory.set(address, value)
+	native.computer.invalidate(address)
+	return native.mark(address, value)
+}
+
+func (native *Native) Input() (int, bool) {
+	return native.computer.read_input()
+}
+
+func (native *Native) Output(value int) {
+	native.computer.write_output(value)
+}
+
+// Stale tells whether the compiled instruction at ip no longer matches
+// memory.
+func (native *Native) Stale(ip int) bool {
+	if len(native.dirty) == 0 || !native.program.covers(ip) {
+		return false
+	}
+
+	for address := ip; address < ip+native.program.sizes[ip]; address++ {
+		if native.dirty[address] {
+			return true
+		}
+	}
+	return false
+}
+
+// mark records whether a cell of compiled code differs from the image.
+func (native *Native) mark(address int, value int) bool {
+	if address >= len(native.program.code) || !native.program.code[address] {
+		return false
+	}
+
+	var was_dirty bool = native.dirty[address]
+	if value != native.program.image[address] {
+		if native.dirty == nil {
+			native.dirty = make(map[int]bool)
+		}
+		native.dirty[address] = true
+	} else {
+		delete(native.dirty, address)
+	}
+
+	return was_dirty || native.dirty[address]
+}
+
+func (program *CompiledProgram) covers(ip int) bool {
+	return ip >= 0 && ip < len(program.sizes) && program.sizes[ip] != 0
+}
+
+// RunCompiled runs like Run, executing the program's compiled code wherever
+// memory still matches the image it was compiled from and interpreting
+// everything else. Tracing and overflow checking only happen in the
+// interpreter, and so does recording a session, as compiled code only counts
+// its instructions when it leaves. With any of those on it is just Run.
+func (computer *IntCodeComputer) RunCompiled(program *CompiledProgram) error {
+	if computer.tracer != nil || computer.overflow_checked || computer.session != nil {
+		return computer.Run()
+	}
+	if computer.state == Halted || computer.state == Faulted {
+		return computer.Step()
+	}
+
+	var native Native = Native{0, 0, computer, program, nil}
+	for address, value := range program.image {
+		if computer.memory.get(address) != value {
+			native.mark(address, computer.memory.get(address))
+		}
+	}
+
+	for {
+		var ip int = computer.memory_pointer
+		if program.covers(ip) && !native.Stale(ip) {
+			computer.state = Running
+			native.RelativeBase = computer.relative_pointer
+			native.Count = 0
+
+			computer.memory_pointer = program.run(&native, ip)
+			computer.relative_pointer = native.RelativeBase
+			computer.instruction_count = computer.instruction_count + native.Count
+		}
+
+		// Compiled code runs most instructions, so those left to the
+		// interpreter are not worth caching
+		err := computer.step(false)
+		if err != nil || computer.state != Running {
+			return err
+		}
+	}
+}
+
+// ----------------------- Compiled Program Struct End -----------------------
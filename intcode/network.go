@@ -0,0 +1,460 @@
+package intcode
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// ----------------------- Topology Struct Start -----------------------
+
+// Packet is what a node sends by outputting a destination address, then x and
+// then y.
+type Packet struct {
+	Source      int
+	Destination int
+	X           int
+	Y           int
+}
+
+// Topology says how many nodes a network has and which of them can send to
+// which. The NAT reaches and is reached by every node.
+type Topology struct {
+	Nodes int
+	links map[[2]int]bool
+}
+
+// FullyConnected lets every node send to every other.
+func FullyConnected(nodes int) Topology {
+	return Topology{nodes, nil}
+}
+
+// Ring lets every node send only to its two neighbours.
+func Ring(nodes int) Topology {
+	var topology Topology = Topology{nodes, make(map[[2]int]bool)}
+	for node := 0; node < nodes; node++ {
+		topology.Connect(node, (node+1)%nodes)
+		topology.Connect((node+1)%nodes, node)
+	}
+	return topology
+}
+
+// Connect adds a link from one node to another, restricting a fully
+// connected topology to the links added.
+func (topology *Topology) Connect(from int, to int) {
+	if topology.links == nil {
+		topology.links = make(map[[2]int]bool)
+	}
+	topology.links[[2]int{from, to}] = true
+}
+
+func (topology Topology) Connected(from int, to int) bool {
+	if to < 0 || to >= topology.Nodes {
+		return false
+	}
+	return topology.links == nil || topology.links[[2]int{from, to}]
+}
+
+// ----------------------- Topology Struct End -----------------------
+
+// ----------------------- Scheduler Struct Start -----------------------
+
+// Scheduler decides the turns of a sweep. Next is given how many instructions
+// each node ran so far and returns the nodes to run, in order, and how many
+// instructions a turn may take, 0 for as many as the node runs before it
+// waits for input.
+type Scheduler interface {
+	Next(usage []int) ([]int, int)
+}
+
+// RoundRobin runs every node in address order until it waits for input.
+type RoundRobin struct{}
+
+func (scheduler RoundRobin) Next(usage []int) ([]int, int) {
+	var order []int = make([]int, len(usage))
+	for node := range order {
+		order[node] = node
+	}
+	return order, 0
+}
+
+// RandomScheduler runs every node until it waits for input, in an order
+// shuffled every sweep. The same seed gives the same orders.
+type RandomScheduler struct {
+	random *rand.Rand
+}
+
+func NewRandomScheduler(seed int64) *RandomScheduler {
+	return &RandomScheduler{rand.New(rand.NewSource(seed))}
+}
+
+func (scheduler *RandomScheduler) Next(usage []int) ([]int, int) {
+	return scheduler.random.Perm(len(usage)), 0
+}
+
+// FairShare runs every node for at most Quantum instructions, those that ran
+// the least first.
+type FairShare struct {
+	Quantum int
+}
+
+func (scheduler FairShare) Next(usage []int) ([]int, int) {
+	order, _ := RoundRobin{}.Next(usage)
+	sort.SliceStable(order, func(first int, second int) bool {
+		return usage[order[first]] < usage[order[second]]
+	})
+	return order, scheduler.Quantum
+}
+
+// ----------------------- Scheduler Struct End -----------------------
+
+// ----------------------- NAT Struct Start -----------------------
+
+// Sweep is what happened while every scheduled node had a turn.
+type Sweep struct {
+	Number int
+	// Packets sent from node to node
+	Sent int
+	// Packets sent to the NAT
+	ToNAT int
+	// Packets sent to no node or over no link
+	Dropped int
+	// Nodes that failed during the sweep, which no longer run
+	Quarantined []int
+	// Whether every running node ended waiting for input with nothing left
+	// to read
+	Waiting bool
+	// Whether the NAT stopped the network
+	Stopped bool
+}
+
+// NATPolicy is the node at a network's NAT address. It is given every packet
+// sent to its address and, after every sweep, can send a packet of its own
+// and stop the network.
+type NATPolicy interface {
+	Address() int
+	Receive(packet Packet)
+	AfterSweep(sweep Sweep) (Packet, bool, bool)
+}
+
+// IdleNAT keeps the last packet it received and sends it to one node after
+// every sweep in which nodes sent nothing to each other. A second such sweep
+// in a row stops the network.
+type IdleNAT struct {
+	address     int
+	send_to     int
+	received    []Packet
+	delivered   []Packet
+	idle_sweeps int
+}
+
+func NewIdleNAT(address int, send_to int) *IdleNAT {
+	return &IdleNAT{address, send_to, make([]Packet, 0), make([]Packet, 0), 0}
+}
+
+func (nat *IdleNAT) Address() int {
+	return nat.address
+}
+
+func (nat *IdleNAT) Receive(packet Packet) {
+	nat.received = append(nat.received, packet)
+}
+
+func (nat *IdleNAT) Received() []Packet {
+	return nat.received
+}
+
+func (nat *IdleNAT) Delivered() []Packet {
+	return nat.delivered
+}
+
+func (nat *IdleNAT) AfterSweep(sweep Sweep) (Packet, bool, bool) {
+	if sweep.Sent != 0 {
+		nat.idle_sweeps = 0
+		return Packet{}, false, false
+	}
+
+	nat.idle_sweeps = nat.idle_sweeps + 1
+	if nat.idle_sweeps > 1 {
+		return Packet{}, false, true
+	}
+	if len(nat.received) == 0 {
+		return Packet{}, false, false
+	}
+
+	var last Packet = nat.received[len(nat.received)-1]
+	var packet Packet = Packet{nat.address, nat.send_to, last.X, last.Y}
+	nat.delivered = append(nat.delivered, packet)
+	return packet, true, false
+}
+
+// RepeatNAT keeps the last packet it received and sends it to one node once
+// every node waits for input with nothing to read. It stops the network when
+// it sends the same y twice in a row.
+type RepeatNAT struct {
+	IdleNAT
+}
+
+func NewRepeatNAT(address int, send_to int) *RepeatNAT {
+	return &RepeatNAT{*NewIdleNAT(address, send_to)}
+}
+
+func (nat *RepeatNAT) AfterSweep(sweep Sweep) (Packet, bool, bool) {
+	if sweep.Sent != 0 || !sweep.Waiting || len(nat.received) == 0 {
+		return Packet{}, false, false
+	}
+
+	var last Packet = nat.received[len(nat.received)-1]
+	if len(nat.delivered) != 0 && nat.delivered[len(nat.delivered)-1].Y == last.Y {
+		return Packet{}, false, true
+	}
+
+	var packet Packet = Packet{nat.address, nat.send_to, last.X, last.Y}
+	nat.delivered = append(nat.delivered, packet)
+	return packet, true, false
+}
+
+// ----------------------- NAT Struct End -----------------------
+
+// ----------------------- Network Struct Start -----------------------
+
+// LinkStats counts the packets that went over a link.
+type LinkStats struct {
+	From      int
+	To        int
+	Sent      int
+	Delivered int
+	// Most packets ever waiting on the link
+	MaxQueued int
+}
+
+type network_packet struct {
+	packet   Packet
+	sequence int
+}
+
+type network_link struct {
+	stats LinkStats
+	queue []network_packet
+}
+
+type network_node struct {
+	computer IntCodeComputer
+	incoming []*network_link
+	fault    error
+}
+
+// Network runs a computer per node of a topology, each started with its
+// address as first input. A node waiting for input is given the x and y of
+// the oldest packet sent to it, or -1 when there is none.
+type Network struct {
+	topology  Topology
+	scheduler Scheduler
+	nat       NATPolicy
+	nodes     []network_node
+	links     map[[2]int]*network_link
+	usage     []int
+	sequence  int
+	sweeps    int
+	ticks     int
+	dropped   int
+	observer  PacketObserver
+}
+
+func NewNetwork(computer IntCodeComputer, topology Topology, scheduler Scheduler, nat NATPolicy) *Network {
+	var network *Network = &Network{topology, scheduler, nat, make([]network_node, topology.Nodes), make(map[[2]int]*network_link), make([]int, topology.Nodes), 0, 0, 0, 0, nil}
+
+	for address := range network.nodes {
+		network.nodes[address].computer = MakeDeepCopy(computer)
+		network.nodes[address].computer.AddInput(address)
+	}
+	return network
+}
+
+// Computer gives access to the computer of a node.
+func (network *Network) Computer(address int) *IntCodeComputer {
+	return &network.nodes[address].computer
+}
+
+// Fault returns the error that quarantined a node, if any.
+func (network *Network) Fault(address int) error {
+	return network.nodes[address].fault
+}
+
+// Dropped counts the packets sent to no node or over no link.
+func (network *Network) Dropped() int {
+	return network.dropped
+}
+
+// Links returns the statistics of every link used, ordered by sender and then
+// receiver.
+func (network *Network) Links() []LinkStats {
+	var stats []LinkStats = make([]LinkStats, 0, len(network.links))
+	for _, link := range network.links {
+		stats = append(stats, link.stats)
+	}
+
+	sort.Slice(stats, func(first int, second int) bool {
+		if stats[first].From != stats[second].From {
+			return stats[first].From < stats[second].From
+		}
+		return stats[first].To < stats[second].To
+	})
+	return stats
+}
+
+// SetObserver starts reporting every packet event to observer, or stops when
+// it is nil.
+func (network *Network) SetObserver(observer PacketObserver) {
+	network.observer = observer
+}
+
+func (network *Network) observe(kind string, packet Packet) {
+	if network.observer != nil {
+		network.observer.Observe(PacketEvent{network.ticks, network.sweeps - 1, kind, packet})
+	}
+}
+
+// Sweep gives a turn to every node the scheduler picks and then lets the NAT
+// act.
+func (network *Network) Sweep() Sweep {
+	var sweep Sweep = Sweep{Number: network.sweeps, Quarantined: make([]int, 0)}
+	network.sweeps = network.sweeps + 1
+
+	order, quantum := network.scheduler.Next(network.usage)
+	for _, address := range order {
+		var node *network_node = &network.nodes[address]
+		if node.fault != nil {
+			// Quarantined nodes no longer run
+			continue
+		}
+
+		network.ticks = network.ticks + 1
+		if node.computer.State() == AwaitingInput && len(node.computer.PendingInput()) == 0 {
+			network.feed(address)
+		}
+
+		var before int = node.computer.InstructionCount()
+		var err error = nil
+		if quantum == 0 {
+			err = node.computer.Run()
+		} else {
+			_, err = node.computer.RunFor(quantum)
+		}
+		network.usage[address] = network.usage[address] + node.computer.InstructionCount() - before
+
+		for len(node.computer.Output()) >= 3 {
+			var output []int = node.computer.ConsumeOutput(3)
+			network.send(Packet{address, output[0], output[1], output[2]}, &sweep)
+		}
+
+		if err != nil {
+			// Quarantine the faulty node, the rest keeps running
+			node.fault = err
+			sweep.Quarantined = append(sweep.Quarantined, address)
+		}
+	}
+
+	sweep.Waiting = true
+	for address := range network.nodes {
+		var node *network_node = &network.nodes[address]
+		if node.fault == nil && (node.computer.State() != AwaitingInput || network.queued(address) != 0) {
+			sweep.Waiting = false
+		}
+	}
+
+	if network.nat != nil {
+		packet, send, stop := network.nat.AfterSweep(sweep)
+		if send && packet.Destination >= 0 && packet.Destination < len(network.nodes) {
+			network.observe(PACKET_FROM_NAT, packet)
+			network.deliver(packet)
+		}
+		sweep.Stopped = stop
+	}
+	return sweep
+}
+
+// Run sweeps until the NAT stops the network, returning the last sweep. A
+// policy that never stops it keeps Run going forever, and a network with no
+// NAT fails with ErrNoNAT; drive those with Sweep instead.
+func (network *Network) Run() (Sweep, error) {
+	if network.nat == nil {
+		return Sweep{}, ErrNoNAT
+	}
+
+	var sweep Sweep = network.Sweep()
+	for !sweep.Stopped {
+		sweep = network.Sweep()
+	}
+	return sweep, nil
+}
+
+func (network *Network) send(packet Packet, sweep *Sweep) {
+	if network.nat != nil && packet.Destination == network.nat.Address() {
+		sweep.ToNAT = sweep.ToNAT + 1
+		network.observe(PACKET_TO_NAT, packet)
+		network.nat.Receive(packet)
+		return
+	}
+
+	if !network.topology.Connected(packet.Source, packet.Destination) {
+		sweep.Dropped = sweep.Dropped + 1
+		network.dropped = network.dropped + 1
+		network.observe(PACKET_DROPPED, packet)
+		return
+	}
+
+	sweep.Sent = sweep.Sent + 1
+	network.observe(PACKET_SENT, packet)
+	network.deliver(packet)
+}
+
+// deliver queues a packet on its link.
+func (network *Network) deliver(packet Packet) {
+	var key [2]int = [2]int{packet.Source, packet.Destination}
+	link, is_set := network.links[key]
+	if !is_set {
+		link = &network_link{LinkStats{packet.Source, packet.Destination, 0, 0, 0}, make([]network_packet, 0)}
+		network.links[key] = link
+
+		var node *network_node = &network.nodes[packet.Destination]
+		node.incoming = append(node.incoming, link)
+	}
+
+	link.queue = append(link.queue, network_packet{packet, network.sequence})
+	network.sequence = network.sequence + 1
+	link.stats.Sent = link.stats.Sent + 1
+	link.stats.MaxQueued = max(link.stats.MaxQueued, len(link.queue))
+}
+
+// feed gives a node the oldest packet waiting on any of its links, or -1.
+func (network *Network) feed(address int) {
+	var node *network_node = &network.nodes[address]
+
+	var oldest *network_link = nil
+	for _, link := range node.incoming {
+		if len(link.queue) != 0 && (oldest == nil || link.queue[0].sequence < oldest.queue[0].sequence) {
+			oldest = link
+		}
+	}
+
+	if oldest == nil {
+		node.computer.AddInput(-1)
+		return
+	}
+
+	var packet Packet = oldest.queue[0].packet
+	oldest.queue = oldest.queue[1:]
+	oldest.stats.Delivered = oldest.stats.Delivered + 1
+	network.observe(PACKET_RECEIVED, packet)
+	node.computer.AddInput(packet.X, packet.Y)
+}
+
+func (network *Network) queued(address int) int {
+	var count int = 0
+	for _, link := range network.nodes[address].incoming {
+		count = count + len(link.queue)
+	}
+	return count
+}
+
+// ----------------------- Network Struct End -----------------------
@@ -0,0 +1,207 @@
+package intcode
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Every node sends 7, 8 to the node two addresses above its own, then keeps
+// reading
+const SEND_TWO_UP string = `
+        IN    [address]
+        ADD   [address], #2, [target]
+        OUT   [target]
+        OUT   #7
+        OUT   #8
+loop:   IN    [read]
+        JZ    #0, loop
+address: db   0
+target: db    0
+read:   db    0
+`
+
+func TestNetworkLinks(t *testing.T) {
+	codes, err := Assemble(SEND_TWO_UP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests []struct {
+		name     string
+		topology Topology
+		links    []LinkStats
+		dropped  int
+	} = []struct {
+		name     string
+		topology Topology
+		links    []LinkStats
+		dropped  int
+	}{
+		{"fully connected", FullyConnected(4), []LinkStats{{0, 2, 1, 1, 1}, {1, 3, 1, 1, 1}}, 2},
+		{"ring", Ring(4), []LinkStats{}, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var network *Network = NewNetwork(NewFromCodes(codes), test.topology, RoundRobin{}, nil)
+			network.Sweep()
+			network.Sweep()
+
+			if !reflect.DeepEqual(network.Links(), test.links) || network.Dropped() != test.dropped {
+				t.Fatalf("links %+v with %d dropped, expected %+v with %d", network.Links(), network.Dropped(), test.links, test.dropped)
+			}
+		})
+	}
+}
+
+func TestNetworkSchedulers(t *testing.T) {
+	var computer IntCodeComputer = load_day(t, "day_23")
+
+	var schedulers []Scheduler = []Scheduler{RoundRobin{}, NewRandomScheduler(1), NewRandomScheduler(2), FairShare{500}}
+	for _, scheduler := range schedulers {
+		var nat *RepeatNAT = NewRepeatNAT(255, 0)
+		var network *Network = NewNetwork(computer, FullyConnected(50), scheduler, nat)
+		_, err := network.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var delivered []Packet = nat.Delivered()
+		if nat.Received()[0].Y != 17541 || delivered[len(delivered)-1].Y != 12415 {
+			t.Fatalf("%T: first packet %+v, last delivered %+v", scheduler, nat.Received()[0], delivered[len(delivered)-1])
+		}
+	}
+}
+
+// Node 0 sends 7, 8 to the NAT, then every node keeps reading
+const SEND_TO_NAT string = `
+        IN    [address]
+        JNZ   [address], loop
+        OUT   #255
+        OUT   #7
+        OUT   #8
+loop:   IN    [read]
+        JZ    #0, loop
+address: db   0
+read:   db    0
+`
+
+func TestIdleNAT(t *testing.T) {
+	codes, err := Assemble(SEND_TO_NAT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first sweep only reaches the NAT, so it is idle and the NAT wakes
+	// node 0, which sends nothing either, stopping the network
+	var nat *IdleNAT = NewIdleNAT(255, 0)
+	var network *Network = NewNetwork(NewFromCodes(codes), FullyConnected(3), RoundRobin{}, nat)
+	sweep, err := network.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sweep.Number != 1 || !reflect.DeepEqual(nat.Received(), []Packet{{0, 255, 7, 8}}) || !reflect.DeepEqual(nat.Delivered(), []Packet{{255, 0, 7, 8}}) {
+		t.Fatalf("stopped at sweep %d having received %+v and delivered %+v", sweep.Number, nat.Received(), nat.Delivered())
+	}
+
+	// Day 23 as its main runs it
+	nat = NewIdleNAT(255, 0)
+	network = NewNetwork(load_day(t, "day_23"), FullyConnected(50), RoundRobin{}, nat)
+	for len(nat.Received()) == 0 {
+		network.Sweep()
+	}
+	if nat.Received()[0].Y != 17541 {
+		t.Fatalf("first packet %+v", nat.Received()[0])
+	}
+	_, err = network.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var received []Packet = nat.Received()
+	if received[len(received)-1].Y != 12415 {
+		t.Fatalf("last packet received %+v", received[len(received)-1])
+	}
+}
+
+func TestNetworkWithoutNAT(t *testing.T) {
+	codes, err := Assemble(SEND_TO_NAT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing would ever stop the network
+	var network *Network = NewNetwork(NewFromCodes(codes), FullyConnected(3), RoundRobin{}, nil)
+	if _, err := network.Run(); !errors.Is(err, ErrNoNAT) {
+		t.Fatalf("ran with no NAT and ' %v '", err)
+	}
+
+	// Packets to the missing NAT are dropped like any other
+	var sweep Sweep = network.Sweep()
+	if sweep.Dropped != 1 || sweep.Stopped {
+		t.Fatalf("sweep %+v, expected one dropped packet", sweep)
+	}
+}
+
+func run_logged(computer IntCodeComputer, scheduler Scheduler) string {
+	var builder strings.Builder
+	var network *Network = NewNetwork(computer, FullyConnected(50), scheduler, NewRepeatNAT(255, 0))
+	network.SetObserver(NewPacketLog(&builder))
+	network.Run()
+	return builder.String()
+}
+
+func TestNetworkSeededLog(t *testing.T) {
+	var computer IntCodeComputer = load_day(t, "day_23")
+
+	var first string = run_logged(computer, NewRandomScheduler(7))
+	var second string = run_logged(computer, NewRandomScheduler(7))
+	if first != second {
+		t.Fatalf("runs with the same seed logged differently")
+	}
+	if !strings.Contains(first, "deliver 255 -> 0") {
+		t.Fatalf("no NAT delivery logged")
+	}
+	if run_logged(computer, NewRandomScheduler(8)) == first {
+		t.Fatalf("runs with different seeds logged the same")
+	}
+}
+
+func TestPacketCapture(t *testing.T) {
+	codes, err := Assemble(SEND_TWO_UP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buffer bytes.Buffer
+	var capture *PacketCapture = NewPacketCapture(&buffer)
+	var network *Network = NewNetwork(NewFromCodes(codes), FullyConnected(3), RoundRobin{}, nil)
+	network.SetObserver(capture)
+	network.Sweep()
+	network.Sweep()
+	if capture.Err() != nil {
+		t.Fatal(capture.Err())
+	}
+
+	events, err := ReadCapture(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected []PacketEvent = []PacketEvent{
+		{1, 0, PACKET_SENT, Packet{0, 2, 7, 8}},
+		{2, 0, PACKET_DROPPED, Packet{1, 3, 7, 8}},
+		{3, 0, PACKET_DROPPED, Packet{2, 4, 7, 8}},
+		{6, 1, PACKET_RECEIVED, Packet{0, 2, 7, 8}},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Fatalf("captured %+v, expected %+v", events, expected)
+	}
+
+	var summary []NodeTraffic = SummarizeCapture(events)
+	var traffic []NodeTraffic = []NodeTraffic{{0, 1, 0, 0, 0, 0}, {1, 0, 1, 0, 0, 0}, {2, 0, 1, 0, 0, 1}}
+	if !reflect.DeepEqual(summary, traffic) {
+		t.Fatalf("summary %+v, expected %+v", summary, traffic)
+	}
+}
@@ -0,0 +1,73 @@
+package intcode
+
+import (
+	"fmt"
+)
+
+// ----------------------- Opcode Struct Start -----------------------
+
+// Opcode is a decoded instruction: the operation and the parameter mode of
+// each argument, least significant first.
+type Opcode struct {
+	Code int
+	Tags []int
+}
+
+// GetOpcode decodes the raw value stored at the instruction pointer.
+func GetOpcode(value int) (Opcode, error) {
+	var opcode int = value % 100
+	var tags []int = make([]int, 0)
+	value = value / 100
+
+	for value != 0 {
+		var tag int = value % 10
+		// Only valid tags
+		if tag < 0 || tag > 2 {
+			return Opcode{}, fmt.Errorf("%w: ' %d '", ErrInvalidParameterMode, tag)
+		}
+
+		tags = append(tags, tag)
+		value = value / 10
+	}
+
+	// Only valid opcodes
+	if (opcode < 1 || opcode > 9) && opcode != 99 {
+		return Opcode{}, fmt.Errorf("%w: ' %d '", ErrUnknownOpcode, opcode)
+	}
+
+	return Opcode{opcode, tags}, nil
+}
+
+// Tag returns the parameter mode of the argument at index, which is position
+// mode when the instruction leaves it out.
+func (opcode Opcode) Tag(index int) int {
+	if index >= len(opcode.Tags) {
+		// By omission type is 0
+		return 0
+	}
+
+	// Else type is given by opcode tag
+	return opcode.Tags[index]
+}
+
+// Mnemonics names every operation the computer implements.
+var Mnemonics map[int]string = map[int]string{
+	1:  "ADD",
+	2:  "MUL",
+	3:  "IN",
+	4:  "OUT",
+	5:  "JNZ",
+	6:  "JZ",
+	7:  "LT",
+	8:  "EQ",
+	9:  "ARB",
+	99: "HLT",
+}
+
+// Size is how many memory cells the instruction takes, itself included.
+func (opcode Opcode) Size() int {
+	number_arg, _ := arguments_of(opcode.Code)
+	return 1 + number_arg
+}
+
+// ----------------------- Opcode Struct End -----------------------
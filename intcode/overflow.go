@@ -0,0 +1,34 @@
+package intcode
+
+import "math"
+
+// ----------------------- Overflow Check Start -----------------------
+
+// SetOverflowCheck makes additions, multiplications, relative base
+// adjustments and relative addresses that wrap around fail with ErrOverflow
+// instead, located at the offending instruction. Checking costs a little
+// speed, so it is off by default; BigComputer never overflows at all.
+func (computer *IntCodeComputer) SetOverflowCheck(enabled bool) {
+	computer.overflow_checked = enabled
+}
+
+func addition_fits(first int, second int) bool {
+	if second > 0 {
+		return first <= math.MaxInt-second
+	}
+	return first >= math.MinInt-second
+}
+
+func multiplication_fits(first int, second int) bool {
+	if first == 0 || second == 0 {
+		return true
+	}
+	// The one product division cannot check, as it overflows too
+	if (first == -1 && second == math.MinInt) || (second == -1 && first == math.MinInt) {
+		return false
+	}
+
+	return (first*second)/second == first
+}
+
+// ----------------------- Overflow Check End -----------------------
@@ -0,0 +1,190 @@
+package intcode
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ----------------------- Packet Log Struct Start -----------------------
+
+// Kinds of packet event
+const (
+	// A node queued a packet for another
+	PACKET_SENT string = "send"
+	// A node sent a packet to no node or over no link
+	PACKET_DROPPED string = "drop"
+	// A node sent a packet to the NAT
+	PACKET_TO_NAT string = "nat"
+	// The NAT sent a packet to a node
+	PACKET_FROM_NAT string = "deliver"
+	// A node read a packet sent to it
+	PACKET_RECEIVED string = "receive"
+)
+
+// PacketEvent is something that happened to a packet. Tick counts the turns
+// nodes were given so far, so events of a turn share it, and the NAT acts on
+// the tick of the last turn of its sweep.
+type PacketEvent struct {
+	Tick   int
+	Sweep  int
+	Kind   string
+	Packet Packet
+}
+
+// PacketObserver is told of every packet event of a network.
+type PacketObserver interface {
+	Observe(event PacketEvent)
+}
+
+// PacketLog writes every event as a line of text. Runs scheduled the same way
+// give the same log, so two logs can be compared with diff.
+type PacketLog struct {
+	writer io.Writer
+}
+
+func NewPacketLog(writer io.Writer) *PacketLog {
+	return &PacketLog{writer}
+}
+
+func (log *PacketLog) Observe(event PacketEvent) {
+	fmt.Fprintf(log.writer, "tick %d sweep %d %-7s %d -> %d x %d y %d\n", event.Tick, event.Sweep, event.Kind,
+		event.Packet.Source, event.Packet.Destination, event.Packet.X, event.Packet.Y)
+}
+
+// ----------------------- Packet Log Struct End -----------------------
+
+// ----------------------- Packet Capture Struct Start -----------------------
+
+// capture_record is a line of a capture, as described on PacketCapture.
+type capture_record struct {
+	Tick  int    `json:"tick"`
+	Sweep int    `json:"sweep"`
+	Kind  string `json:"kind"`
+	From  int    `json:"from"`
+	To    int    `json:"to"`
+	X     int    `json:"x"`
+	Y     int    `json:"y"`
+}
+
+var packet_kinds map[string]bool = map[string]bool{
+	PACKET_SENT:     true,
+	PACKET_DROPPED:  true,
+	PACKET_TO_NAT:   true,
+	PACKET_FROM_NAT: true,
+	PACKET_RECEIVED: true,
+}
+
+// PacketCapture writes every event to a capture, to be read back with
+// ReadCapture. Writing stops at the first error, reported by Err.
+//
+// A capture is JSON lines, one event per line:
+//
+//	{"tick":524,"sweep":10,"kind":"nat","from":1,"to":255,"x":76261,"y":17541}
+//
+// Every field is always present:
+//
+//   - tick and sweep are the Tick and Sweep of the PacketEvent
+//   - kind is one of PACKET_SENT ("send"), PACKET_DROPPED ("drop"),
+//     PACKET_TO_NAT ("nat"), PACKET_FROM_NAT ("deliver") and
+//     PACKET_RECEIVED ("receive")
+//   - from and to are the Source and Destination of the packet, the NAT
+//     being its address
+//   - x and y are the values the packet carries
+//
+// Lines appear in the order the events happened, so ticks never decrease.
+type PacketCapture struct {
+	encoder *json.Encoder
+	err     error
+}
+
+func NewPacketCapture(writer io.Writer) *PacketCapture {
+	return &PacketCapture{json.NewEncoder(writer), nil}
+}
+
+func (capture *PacketCapture) Observe(event PacketEvent) {
+	if capture.err != nil {
+		return
+	}
+
+	var packet Packet = event.Packet
+	capture.err = capture.encoder.Encode(capture_record{event.Tick, event.Sweep, event.Kind, packet.Source, packet.Destination, packet.X, packet.Y})
+}
+
+func (capture *PacketCapture) Err() error {
+	return capture.err
+}
+
+// ReadCapture reads back the events of a capture in the format written by
+// PacketCapture. Unknown fields are ignored, but an unknown kind or a line
+// that is not JSON fails with ErrCaptureInvalid.
+func ReadCapture(reader io.Reader) ([]PacketEvent, error) {
+	var events []PacketEvent = make([]PacketEvent, 0)
+
+	var decoder *json.Decoder = json.NewDecoder(bufio.NewReader(reader))
+	for {
+		var record capture_record
+		err := decoder.Decode(&record)
+		if err == io.EOF {
+			return events, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCaptureInvalid, err)
+		}
+
+		if !packet_kinds[record.Kind] {
+			return nil, fmt.Errorf("%w: event kind ' %s '", ErrCaptureInvalid, record.Kind)
+		}
+		events = append(events, PacketEvent{record.Tick, record.Sweep, record.Kind, Packet{record.From, record.To, record.X, record.Y}})
+	}
+}
+
+// NodeTraffic counts the packets a node took part in.
+type NodeTraffic struct {
+	Address  int
+	Sent     int
+	Dropped  int
+	ToNAT    int
+	FromNAT  int
+	Received int
+}
+
+// SummarizeCapture counts the traffic of every node in the events, ordered by
+// address. The NAT shows as the node sending its deliveries.
+func SummarizeCapture(events []PacketEvent) []NodeTraffic {
+	var traffic map[int]*NodeTraffic = make(map[int]*NodeTraffic)
+	var node = func(address int) *NodeTraffic {
+		if _, is_set := traffic[address]; !is_set {
+			traffic[address] = &NodeTraffic{Address: address}
+		}
+		return traffic[address]
+	}
+
+	for _, event := range events {
+		switch event.Kind {
+		case PACKET_SENT:
+			node(event.Packet.Source).Sent++
+		case PACKET_DROPPED:
+			node(event.Packet.Source).Dropped++
+		case PACKET_TO_NAT:
+			node(event.Packet.Source).ToNAT++
+		case PACKET_FROM_NAT:
+			node(event.Packet.Source).Sent++
+			node(event.Packet.Destination).FromNAT++
+		case PACKET_RECEIVED:
+			node(event.Packet.Destination).Received++
+		}
+	}
+
+	var summary []NodeTraffic = make([]NodeTraffic, 0, len(traffic))
+	for _, node_traffic := range traffic {
+		summary = append(summary, *node_traffic)
+	}
+	sort.Slice(summary, func(first int, second int) bool {
+		return summary[first].Address < summary[second].Address
+	})
+	return summary
+}
+
+// ----------------------- Packet Capture Struct End -----------------------
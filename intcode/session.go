@@ -0,0 +1,206 @@
+package intcode
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ----------------------- Session Struct Start -----------------------
+
+// Kinds of session event
+const (
+	SESSION_INPUT  string = "in"
+	SESSION_OUTPUT string = "out"
+)
+
+// SessionEvent is a value read or written by the program, with how many
+// instructions it had executed before.
+type SessionEvent struct {
+	Kind         string `json:"kind"`
+	Value        int    `json:"value"`
+	Instructions int    `json:"instructions"`
+}
+
+func (event SessionEvent) String() string {
+	var kind string = "input"
+	if event.Kind == SESSION_OUTPUT {
+		kind = "output"
+	}
+	return fmt.Sprintf("%s ' %d ' after %d instructions", kind, event.Value, event.Instructions)
+}
+
+// Session is everything a computer read and wrote, in order.
+type Session struct {
+	Events []SessionEvent
+}
+
+// Record starts logging every value the computer reads or writes to session,
+// or stops when it is nil. Copies made with MakeDeepCopy do not record.
+func (computer *IntCodeComputer) Record(session *Session) {
+	computer.session = session
+}
+
+func (session *Session) record(kind string, value int, instructions int) {
+	session.Events = append(session.Events, SessionEvent{kind, value, instructions})
+}
+
+// Inputs returns the values read, in order.
+func (session *Session) Inputs() []int {
+	var inputs []int = make([]int, 0)
+	for _, event := range session.Events {
+		if event.Kind == SESSION_INPUT {
+			inputs = append(inputs, event.Value)
+		}
+	}
+	return inputs
+}
+
+// Outputs returns the values written, in order.
+func (session *Session) Outputs() []int {
+	var outputs []int = make([]int, 0)
+	for _, event := range session.Events {
+		if event.Kind == SESSION_OUTPUT {
+			outputs = append(outputs, event.Value)
+		}
+	}
+	return outputs
+}
+
+// Save writes the session as JSON lines, one event per line.
+func (session *Session) Save(writer io.Writer) error {
+	var encoder *json.Encoder = json.NewEncoder(writer)
+	for _, event := range session.Events {
+		err := encoder.Encode(event)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadSession reads a session written with Save.
+func LoadSession(reader io.Reader) (*Session, error) {
+	var session *Session = &Session{make([]SessionEvent, 0)}
+
+	var decoder *json.Decoder = json.NewDecoder(bufio.NewReader(reader))
+	for {
+		var event SessionEvent
+		err := decoder.Decode(&event)
+		if err == io.EOF {
+			return session, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSessionInvalid, err)
+		}
+
+		if event.Kind != SESSION_INPUT && event.Kind != SESSION_OUTPUT {
+			return nil, fmt.Errorf("%w: event kind ' %s '", ErrSessionInvalid, event.Kind)
+		}
+		session.Events = append(session.Events, event)
+	}
+}
+
+func (session *Session) SaveFile(file_name string) error {
+	file, err := os.Create(file_name)
+	if err != nil {
+		return err
+	}
+
+	err = session.Save(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func LoadSessionFile(file_name string) (*Session, error) {
+	file, err := os.Open(file_name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return LoadSession(file)
+}
+
+// ----------------------- Session Struct End -----------------------
+
+// ----------------------- Replay Struct Start -----------------------
+
+// Divergence is the first event of a replay that did not happen as recorded.
+// Actual is nil when the program stopped, or went past the instruction count
+// of the expected event, without reading or writing anything.
+type Divergence struct {
+	Index    int
+	Expected SessionEvent
+	Actual   *SessionEvent
+}
+
+func (divergence Divergence) String() string {
+	if divergence.Actual == nil {
+		return fmt.Sprintf("event %d: expected %v, got nothing", divergence.Index, divergence.Expected)
+	}
+	return fmt.Sprintf("event %d: expected %v, got %v", divergence.Index, divergence.Expected, *divergence.Actual)
+}
+
+// replay_source gives back the recorded inputs.
+type replay_source struct {
+	values []int
+	index  int
+}
+
+func (source *replay_source) Read() (int, bool) {
+	if source.index >= len(source.values) {
+		return 0, false
+	}
+
+	source.index = source.index + 1
+	return source.values[source.index-1], true
+}
+
+// Replay runs the computer through a recorded session, feeding it the inputs
+// it read, and stops once every event happened again or at the first one that
+// did not, which it returns. The computer should be in the state the session
+// started from, usually fresh. Its own input is ignored, and an error is only
+// returned when the program fails.
+func Replay(computer *IntCodeComputer, session *Session) (*Divergence, error) {
+	var replayed Session = Session{make([]SessionEvent, 0, len(session.Events))}
+	var source *replay_source = &replay_source{session.Inputs(), 0}
+
+	var previous_source InputSource = computer.input_source
+	var previous_session *Session = computer.session
+	computer.SetInput(source)
+	computer.Record(&replayed)
+	defer computer.SetInput(previous_source)
+	defer computer.Record(previous_session)
+
+	var index int = 0
+	for index < len(session.Events) {
+		var expected SessionEvent = session.Events[index]
+		if index < len(replayed.Events) {
+			var actual SessionEvent = replayed.Events[index]
+			if actual != expected {
+				return &Divergence{index, expected, &actual}, nil
+			}
+			index = index + 1
+			continue
+		}
+
+		var waiting bool = computer.state == AwaitingInput && source.index >= len(source.values)
+		if computer.state == Halted || waiting || computer.instruction_count > expected.Instructions {
+			return &Divergence{index, expected, nil}, nil
+		}
+
+		err := computer.Step()
+		if err != nil {
+			return &Divergence{index, expected, nil}, err
+		}
+	}
+
+	return nil, nil
+}
+
+// ----------------------- Replay Struct End -----------------------
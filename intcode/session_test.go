@@ -0,0 +1,79 @@
+package intcode
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func record_echo(t *testing.T, text string) *Session {
+	computer, err := New(ASCII_ECHO)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var session *Session = &Session{}
+	computer.Record(session)
+	computer.AddInput(EncodeASCII(text)...)
+	err = computer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session
+}
+
+func TestSessionSaveLoad(t *testing.T) {
+	var session *Session = record_echo(t, "hi\n")
+	if !reflect.DeepEqual(session.Inputs(), EncodeASCII("hi\n")) || !reflect.DeepEqual(session.Outputs(), []int{104, 105, 10, 1000}) {
+		t.Fatalf("recorded inputs %v and outputs %v", session.Inputs(), session.Outputs())
+	}
+
+	var buffer bytes.Buffer
+	err := session.Save(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSession(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Events, session.Events) {
+		t.Fatalf("loaded %v, saved %v", loaded.Events, session.Events)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	var session *Session = record_echo(t, "north\n")
+
+	computer, _ := New(ASCII_ECHO)
+	divergence, err := Replay(&computer, session)
+	if err != nil || divergence != nil {
+		t.Fatalf("replay diverged at %v with ' %v '", divergence, err)
+	}
+
+	// The program echoes what it reads, so a changed input changes the output
+	// right after it
+	session.Events[4].Value = int('x')
+	computer, _ = New(ASCII_ECHO)
+	divergence, err = Replay(&computer, session)
+	if err != nil || divergence == nil || divergence.Index != 5 || divergence.Actual == nil || divergence.Actual.Value != int('x') {
+		t.Fatalf("replay diverged at %v with ' %v ', expected event 5", divergence, err)
+	}
+
+	// Events past the end of the program never happen
+	session.Events[4].Value = int('r')
+	session.Events = append(session.Events, SessionEvent{SESSION_OUTPUT, 7, 1000})
+	computer, _ = New(ASCII_ECHO)
+	divergence, err = Replay(&computer, session)
+	if err != nil || divergence == nil || divergence.Index != len(session.Events)-1 || divergence.Actual != nil {
+		t.Fatalf("replay diverged at %v with ' %v ', expected the last event missing", divergence, err)
+	}
+}
+
+func TestLoadSessionInvalid(t *testing.T) {
+	_, err := LoadSession(bytes.NewBufferString("{\"kind\":\"sideways\",\"value\":1,\"instructions\":0}\n"))
+	if !errors.Is(err, ErrSessionInvalid) {
+		t.Fatalf("loaded with ' %v ', expected invalid session", err)
+	}
+}
@@ -0,0 +1,230 @@
+package intcode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ----------------------- Snapshot Struct Start -----------------------
+
+// SNAPSHOT_VERSION is written in every snapshot, and Load accepts it and
+// every earlier version. It changes whenever the meaning of a field does.
+// Version 2 added sparse memory, kept as the cells written instead of a flat
+// image.
+const SNAPSHOT_VERSION int = 2
+
+// FaultSnapshot keeps what is needed to report a fault again: its kind, as
+// one of the names in fault_kinds, and where it happened.
+type FaultSnapshot struct {
+	Kind        string `json:"kind"`
+	Message     string `json:"message"`
+	Pointer     int    `json:"pointer"`
+	Instruction int    `json:"instruction"`
+}
+
+// Snapshot is the whole state of a computer. Input sources, output sinks and
+// tracers are not part of it and have to be attached again after restoring.
+type Snapshot struct {
+	Version          int            `json:"version"`
+	State            string         `json:"state"`
+	Pointer          int            `json:"ip"`
+	RelativeBase     int            `json:"rb"`
+	MemoryDefault    int            `json:"memory_default"`
+	MemoryModel      string         `json:"memory_model,omitempty"`
+	MemorySize       int            `json:"memory_size,omitempty"`
+	Memory           []int          `json:"memory,omitempty"`
+	Cells            map[int]int    `json:"cells,omitempty"`
+	Input            []int          `json:"input"`
+	Output           []int          `json:"output"`
+	OutputCount      int            `json:"output_count"`
+	InstructionCount int            `json:"instruction_count"`
+	OverflowChecked  bool           `json:"overflow_checked,omitempty"`
+	Fault            *FaultSnapshot `json:"fault,omitempty"`
+}
+
+var fault_kinds map[string]error = map[string]error{
+	"unknown_opcode":         ErrUnknownOpcode,
+	"invalid_parameter_mode": ErrInvalidParameterMode,
+	"write_immediate_mode":   ErrWriteImmediateMode,
+	"negative_address":       ErrNegativeAddress,
+	"overflow":               ErrOverflow,
+	"address_out_of_range":   ErrAddressOutOfRange,
+}
+
+// restored_fault stands in for a fault read from a snapshot, still matching
+// its kind with errors.Is.
+type restored_fault struct {
+	kind    error
+	message string
+}
+
+func (fault *restored_fault) Error() string {
+	return fault.message
+}
+
+func (fault *restored_fault) Unwrap() error {
+	return fault.kind
+}
+
+// Snapshot captures the computer so it can be restored later, even by another
+// process.
+func (computer *IntCodeComputer) Snapshot() Snapshot {
+	var snapshot Snapshot = Snapshot{
+		Version:          SNAPSHOT_VERSION,
+		State:            computer.state.String(),
+		Pointer:          computer.memory_pointer,
+		RelativeBase:     computer.relative_pointer,
+		MemoryDefault:    computer.memory.default_value,
+		MemoryModel:      computer.memory.model().String(),
+		MemorySize:       computer.memory.size,
+		Memory:           nil,
+		Cells:            nil,
+		Input:            append([]int{}, computer.PendingInput()...),
+		Output:           append([]int{}, computer.output...),
+		OutputCount:      computer.output_count,
+		InstructionCount: computer.instruction_count,
+		OverflowChecked:  computer.overflow_checked,
+		Fault:            nil,
+	}
+
+	if computer.memory.model() == SparseMemory {
+		snapshot.Cells = computer.memory.written()
+	} else {
+		snapshot.Memory = computer.memory.cells()
+	}
+
+	var instruction_error *InstructionError
+	if computer.fault != nil && errors.As(computer.fault, &instruction_error) {
+		var fault FaultSnapshot = FaultSnapshot{"", instruction_error.Err.Error(), instruction_error.Pointer, instruction_error.Instruction}
+		for name, kind := range fault_kinds {
+			if errors.Is(instruction_error.Err, kind) {
+				fault.Kind = name
+			}
+		}
+		snapshot.Fault = &fault
+	}
+
+	return snapshot
+}
+
+// Restore builds a computer from a snapshot, checking it is one this version
+// can run.
+func Restore(snapshot Snapshot) (IntCodeComputer, error) {
+	if snapshot.Version < 1 || snapshot.Version > SNAPSHOT_VERSION {
+		return IntCodeComputer{}, fmt.Errorf("%w: ' %d '", ErrSnapshotVersion, snapshot.Version)
+	}
+
+	var state State = Booting
+	var state_found bool = false
+	for candidate, name := range state_names {
+		if name == snapshot.State {
+			state = candidate
+			state_found = true
+		}
+	}
+	if !state_found {
+		return IntCodeComputer{}, fmt.Errorf("%w: state ' %s '", ErrSnapshotInvalid, snapshot.State)
+	}
+
+	// Version 1 only had dense memory, sized by its image
+	var model MemoryModel = DenseMemory
+	var memory_size int = len(snapshot.Memory)
+	if snapshot.Version >= 2 {
+		var model_found bool = false
+		for candidate, name := range memory_model_names {
+			if name == snapshot.MemoryModel {
+				model = candidate
+				model_found = true
+			}
+		}
+		if !model_found {
+			return IntCodeComputer{}, fmt.Errorf("%w: memory model ' %s '", ErrSnapshotInvalid, snapshot.MemoryModel)
+		}
+		memory_size = snapshot.MemorySize
+	}
+	if memory_size < len(snapshot.Memory) || (model == DenseMemory && memory_size > MAX_DENSE_ADDRESS+1) {
+		return IntCodeComputer{}, fmt.Errorf("%w: memory size ' %d '", ErrSnapshotInvalid, memory_size)
+	}
+	if snapshot.Pointer < 0 || snapshot.Pointer > memory_size {
+		return IntCodeComputer{}, fmt.Errorf("%w: ip ' %d '", ErrSnapshotInvalid, snapshot.Pointer)
+	}
+
+	var computer IntCodeComputer = NewFromCodes(nil)
+	computer.memory = new_paged_memory(snapshot.Memory, snapshot.MemoryDefault, model)
+	for address, value := range snapshot.Cells {
+		if address < 0 || address >= memory_size {
+			return IntCodeComputer{}, fmt.Errorf("%w: cell ' %d '", ErrSnapshotInvalid, address)
+		}
+		computer.memory.set(address, value)
+	}
+	computer.memory.size = memory_size
+	computer.state = state
+	computer.memory_pointer = snapshot.Pointer
+	computer.relative_pointer = snapshot.RelativeBase
+	computer.input = append(computer.input, snapshot.Input...)
+	computer.output = append(computer.output, snapshot.Output...)
+	computer.output_count = snapshot.OutputCount
+	computer.instruction_count = snapshot.InstructionCount
+	computer.overflow_checked = snapshot.OverflowChecked
+
+	if state == Faulted {
+		if snapshot.Fault == nil {
+			return IntCodeComputer{}, fmt.Errorf("%w: faulted without a fault", ErrSnapshotInvalid)
+		}
+		var fault error = errors.New(snapshot.Fault.Message)
+		if kind, is_set := fault_kinds[snapshot.Fault.Kind]; is_set {
+			fault = &restored_fault{kind, snapshot.Fault.Message}
+		}
+		computer.fault = &InstructionError{fault, snapshot.Fault.Pointer, snapshot.Fault.Instruction}
+	}
+
+	return computer, nil
+}
+
+// Save writes a snapshot of the computer as JSON.
+func (computer *IntCodeComputer) Save(writer io.Writer) error {
+	var encoder *json.Encoder = json.NewEncoder(writer)
+	return encoder.Encode(computer.Snapshot())
+}
+
+// Load reads a computer saved with Save.
+func Load(reader io.Reader) (IntCodeComputer, error) {
+	var snapshot Snapshot
+	err := json.NewDecoder(reader).Decode(&snapshot)
+	if err != nil {
+		return IntCodeComputer{}, fmt.Errorf("%w: %v", ErrSnapshotInvalid, err)
+	}
+
+	return Restore(snapshot)
+}
+
+// SaveFile checkpoints the computer to a file.
+func (computer *IntCodeComputer) SaveFile(file_name string) error {
+	file, err := os.Create(file_name)
+	if err != nil {
+		return err
+	}
+
+	err = computer.Save(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// LoadFile resumes a computer checkpointed with SaveFile.
+func LoadFile(file_name string) (IntCodeComputer, error) {
+	file, err := os.Open(file_name)
+	if err != nil {
+		return IntCodeComputer{}, err
+	}
+	defer file.Close()
+
+	return Load(file)
+}
+
+// ----------------------- Snapshot Struct End -----------------------
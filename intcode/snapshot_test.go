@@ -0,0 +1,131 @@
+package intcode
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// save_and_load passes the computer through its JSON snapshot.
+func save_and_load(t *testing.T, computer *IntCodeComputer) IntCodeComputer {
+	var buffer bytes.Buffer
+	err := computer.Save(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := Load(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return restored
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	codes, err := Assemble(COUNTDOWN_SOURCE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Stop halfway through the countdown from 3, with an input left over
+	var computer IntCodeComputer = NewFromCodes(codes)
+	computer.AddInput(3, 8)
+	_, err = computer.RunFor(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	computer.WriteMemory(100, 7)
+
+	var restored IntCodeComputer = save_and_load(t, &computer)
+	if !reflect.DeepEqual(restored.Snapshot(), computer.Snapshot()) {
+		t.Fatalf("restored %+v, saved %+v", restored.Snapshot(), computer.Snapshot())
+	}
+	if restored.memory.model() != DenseMemory || restored.Pointer() != 6 || restored.InstructionCount() != 5 {
+		t.Fatalf("restored %v memory at ' %d ' after %d instructions", restored.memory.model(), restored.Pointer(), restored.InstructionCount())
+	}
+
+	// Both finish the same way
+	for _, machine := range []*IntCodeComputer{&computer, &restored} {
+		err = machine.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(restored.Output(), []int{2, 1, 0}) || !reflect.DeepEqual(restored.PendingInput(), []int{8}) {
+		t.Fatalf("restored output %v leaving %v", restored.Output(), restored.PendingInput())
+	}
+	if !reflect.DeepEqual(restored.Snapshot(), computer.Snapshot()) {
+		t.Fatalf("restored ended as %+v, saved as %+v", restored.Snapshot(), computer.Snapshot())
+	}
+}
+
+func TestSnapshotFaults(t *testing.T) {
+	var tests []struct {
+		name    string
+		codes   []int
+		checked bool
+		kind    error
+	} = []struct {
+		name    string
+		codes   []int
+		checked bool
+		kind    error
+	}{
+		{"unknown opcode", []int{42}, false, ErrUnknownOpcode},
+		{"invalid parameter mode", []int{301, 0, 0, 0, 99}, false, ErrInvalidParameterMode},
+		{"write immediate mode", []int{11101, 1, 1, 0, 99}, false, ErrWriteImmediateMode},
+		{"negative address", []int{1101, 1, 1, -5, 99}, false, ErrNegativeAddress},
+		{"overflow", []int{1102, math.MaxInt, 2, 0, 99}, true, ErrOverflow},
+		{"address out of range", FAR_RELATIVE_WRITE, false, ErrAddressOutOfRange},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var computer IntCodeComputer = NewFromCodes(test.codes)
+			computer.SetOverflowCheck(test.checked)
+			fault := computer.Run()
+			if !errors.Is(fault, test.kind) {
+				t.Fatalf("ran into ' %v '", fault)
+			}
+
+			var restored IntCodeComputer = save_and_load(t, &computer)
+			err := restored.Run()
+			if restored.State() != Faulted || !errors.Is(err, test.kind) {
+				t.Fatalf("restored ' %v ' with ' %v '", restored.State(), err)
+			}
+			if err.Error() != fault.Error() {
+				t.Fatalf("restored fault ' %v ', saved ' %v '", err, fault)
+			}
+		})
+	}
+}
+
+func TestSnapshotVersions(t *testing.T) {
+	var computer IntCodeComputer = NewFromCodes([]int{104, 5, 99})
+	var snapshot Snapshot = computer.Snapshot()
+
+	for _, version := range []int{0, SNAPSHOT_VERSION + 1} {
+		snapshot.Version = version
+		if _, err := Restore(snapshot); !errors.Is(err, ErrSnapshotVersion) {
+			t.Fatalf("restored version ' %d ' with ' %v '", version, err)
+		}
+	}
+
+	// Version 1 snapshots only had a dense image
+	var old Snapshot = Snapshot{Version: 1, State: "booting", Memory: []int{104, 5, 99}, Input: []int{}, Output: []int{}}
+	restored, err := Restore(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = restored.Run()
+	if err != nil || !reflect.DeepEqual(restored.Output(), []int{5}) {
+		t.Fatalf("version 1 snapshot output %v with ' %v '", restored.Output(), err)
+	}
+
+	snapshot.Version = SNAPSHOT_VERSION
+	snapshot.MemorySize = MAX_DENSE_ADDRESS + 2
+	if _, err := Restore(snapshot); !errors.Is(err, ErrSnapshotInvalid) {
+		t.Fatalf("restored dense memory past its bound with ' %v '", err)
+	}
+}
@@ -0,0 +1,424 @@
+package intcode
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ----------------------- Expression Struct Start -----------------------
+
+type ExpressionKind int
+
+const (
+	Constant ExpressionKind = iota
+	Symbol
+	Sum
+	Product
+	LessThan
+	Equals
+	// Unknown is a value read through an address that depends on symbols
+	Unknown
+)
+
+// Expression is a value computed from symbols. Cells lists the addresses of
+// the program whose initial values were used to compute it.
+type Expression struct {
+	Kind     ExpressionKind
+	Value    int
+	Name     string
+	Operands []*Expression
+	Cells    []int
+}
+
+func NewConstant(value int) *Expression {
+	return &Expression{Constant, value, "", nil, nil}
+}
+
+func NewSymbol(name string) *Expression {
+	return &Expression{Symbol, 0, name, nil, nil}
+}
+
+// combine builds an operation over two values, folding it when both are
+// constant.
+func combine(kind ExpressionKind, first *Expression, second *Expression) *Expression {
+	var cells []int = merge_cells(first.Cells, second.Cells)
+	if first.Kind != Constant || second.Kind != Constant {
+		return &Expression{kind, 0, "", []*Expression{first, second}, cells}
+	}
+
+	var value int = 0
+	switch kind {
+	case Sum:
+		value = first.Value + second.Value
+	case Product:
+		value = first.Value * second.Value
+	case LessThan:
+		if first.Value < second.Value {
+			value = 1
+		}
+	case Equals:
+		if first.Value == second.Value {
+			value = 1
+		}
+	}
+	return &Expression{Constant, value, "", nil, cells}
+}
+
+func merge_cells(first []int, second []int) []int {
+	if len(second) == 0 {
+		return first
+	}
+	if len(first) == 0 {
+		return second
+	}
+
+	var set map[int]bool = make(map[int]bool, len(first)+len(second))
+	for _, cell := range first {
+		set[cell] = true
+	}
+	for _, cell := range second {
+		set[cell] = true
+	}
+
+	var cells []int = make([]int, 0, len(set))
+	for cell := range set {
+		cells = append(cells, cell)
+	}
+	sort.Ints(cells)
+	return cells
+}
+
+// Symbols lists the symbols the value depends on, sorted.
+func (expression *Expression) Symbols() []string {
+	var set map[string]bool = make(map[string]bool)
+	var collect func(current *Expression)
+	collect = func(current *Expression) {
+		if current.Kind == Symbol {
+			set[current.Name] = true
+		}
+		for _, operand := range current.Operands {
+			collect(operand)
+		}
+	}
+	collect(expression)
+
+	var symbols []string = make([]string, 0, len(set))
+	for symbol := range set {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Evaluate computes the value for the given symbol values. It fails when a
+// symbol is missing or the value is Unknown.
+func (expression *Expression) Evaluate(values map[string]int) (int, bool) {
+	switch expression.Kind {
+	case Constant:
+		return expression.Value, true
+	case Symbol:
+		value, is_set := values[expression.Name]
+		return value, is_set
+	case Unknown:
+		return 0, false
+	}
+
+	first, first_known := expression.Operands[0].Evaluate(values)
+	second, second_known := expression.Operands[1].Evaluate(values)
+	if !first_known || !second_known {
+		return 0, false
+	}
+	return combine(expression.Kind, NewConstant(first), NewConstant(second)).Value, true
+}
+
+func (expression *Expression) String() string {
+	switch expression.Kind {
+	case Constant:
+		return fmt.Sprintf("%d", expression.Value)
+	case Symbol:
+		return expression.Name
+	case Unknown:
+		return fmt.Sprintf("[%v]", expression.Operands[0])
+	}
+
+	var operators map[ExpressionKind]string = map[ExpressionKind]string{Sum: "+", Product: "*", LessThan: "<", Equals: "=="}
+	return fmt.Sprintf("(%v %s %v)", expression.Operands[0], operators[expression.Kind], expression.Operands[1])
+}
+
+// ----------------------- Expression Struct End -----------------------
+
+// ----------------------- Linear Struct Start -----------------------
+
+// Linear is a sum of symbols each multiplied by a coefficient, plus a
+// constant.
+type Linear struct {
+	Constant     int
+	Coefficients map[string]int
+}
+
+// Range bounds the values tried for a symbol, High excluded.
+type Range struct {
+	Symbol string
+	Low    int
+	High   int
+}
+
+// Linear rewrites the value as a linear combination of its symbols, when it
+// is one.
+func (expression *Expression) Linear() (Linear, bool) {
+	switch expression.Kind {
+	case Constant:
+		return Linear{expression.Value, map[string]int{}}, true
+	case Symbol:
+		return Linear{0, map[string]int{expression.Name: 1}}, true
+	case Sum, Product:
+	default:
+		return Linear{}, false
+	}
+
+	first, first_linear := expression.Operands[0].Linear()
+	second, second_linear := expression.Operands[1].Linear()
+	if !first_linear || !second_linear {
+		return Linear{}, false
+	}
+
+	if expression.Kind == Sum {
+		var coefficients map[string]int = map[string]int{}
+		for symbol, coefficient := range first.Coefficients {
+			coefficients[symbol] = coefficients[symbol] + coefficient
+		}
+		for symbol, coefficient := range second.Coefficients {
+			coefficients[symbol] = coefficients[symbol] + coefficient
+		}
+		return Linear{first.Constant + second.Constant, coefficients}, true
+	}
+
+	// Only products by a constant stay linear
+	if len(first.Coefficients) != 0 && len(second.Coefficients) != 0 {
+		return Linear{}, false
+	}
+	if len(first.Coefficients) != 0 {
+		first, second = second, first
+	}
+	var coefficients map[string]int = map[string]int{}
+	for symbol, coefficient := range second.Coefficients {
+		coefficients[symbol] = coefficient * first.Constant
+	}
+	return Linear{first.Constant * second.Constant, coefficients}, true
+}
+
+func (linear Linear) String() string {
+	var symbols []string = make([]string, 0, len(linear.Coefficients))
+	for symbol, coefficient := range linear.Coefficients {
+		if coefficient != 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+
+	var terms []string = make([]string, 0, len(symbols)+1)
+	for _, symbol := range symbols {
+		terms = append(terms, fmt.Sprintf("%d * %s", linear.Coefficients[symbol], symbol))
+	}
+	terms = append(terms, fmt.Sprintf("%d", linear.Constant))
+	return strings.Join(terms, " + ")
+}
+
+// Solve finds symbol values within the ranges making the combination equal
+// to target. Earlier ranges are tried in order, from their low end, and the
+// last symbol is solved for directly, so the solution is the first a search
+// nesting the ranges in that order would find.
+func (linear Linear) Solve(target int, ranges ...Range) (map[string]int, bool) {
+	for symbol, coefficient := range linear.Coefficients {
+		var bounded bool = false
+		for _, symbol_range := range ranges {
+			bounded = bounded || symbol_range.Symbol == symbol
+		}
+		if coefficient != 0 && !bounded {
+			return nil, false
+		}
+	}
+	if len(ranges) == 0 {
+		return map[string]int{}, linear.Constant == target
+	}
+
+	var values map[string]int = make(map[string]int, len(ranges))
+	var search func(index int, remaining int) bool
+	search = func(index int, remaining int) bool {
+		var current Range = ranges[index]
+		var coefficient int = linear.Coefficients[current.Symbol]
+
+		if index == len(ranges)-1 {
+			if coefficient == 0 {
+				values[current.Symbol] = current.Low
+				return remaining == 0 && current.Low < current.High
+			}
+			if remaining%coefficient != 0 {
+				return false
+			}
+			var value int = remaining / coefficient
+			values[current.Symbol] = value
+			return value >= current.Low && value < current.High
+		}
+
+		for value := current.Low; value < current.High; value++ {
+			values[current.Symbol] = value
+			if search(index+1, remaining-coefficient*value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !search(0, target-linear.Constant) {
+		return nil, false
+	}
+	return values, true
+}
+
+// ----------------------- Linear Struct End -----------------------
+
+// ----------------------- Symbolic Execution Start -----------------------
+
+const MAX_SYMBOLIC_STEPS int = 1000000
+
+// SymbolicState is what a program left once run symbolically.
+type SymbolicState struct {
+	codes   []int
+	memory  map[int]*Expression
+	Output  []*Expression
+	Inputs  int
+	Steps   int
+	Pointer int
+}
+
+// Cell returns the value the program left at the address.
+func (state *SymbolicState) Cell(address int) *Expression {
+	value, is_set := state.memory[address]
+	if is_set {
+		return value
+	}
+
+	if address >= 0 && address < len(state.codes) {
+		return &Expression{Constant, state.codes[address], "", nil, []int{address}}
+	}
+	return NewConstant(0)
+}
+
+// Evaluate runs a program until it halts with the cells in symbols holding
+// the named symbol instead of their value. Every input instruction reads a
+// new symbol, input_0, input_1 and so on. Values flow through arithmetic and
+// comparisons as expressions, but the instructions run, the addresses
+// written and every branch taken have to be known, or ErrSymbolicControl
+// is returned.
+func Evaluate(codes []int, symbols map[int]string) (*SymbolicState, error) {
+	var state *SymbolicState = &SymbolicState{codes, make(map[int]*Expression), make([]*Expression, 0), 0, 0, 0}
+	for address, name := range symbols {
+		state.memory[address] = NewSymbol(name)
+	}
+
+	var relative_base int = 0
+	for state.Steps = 0; state.Steps < MAX_SYMBOLIC_STEPS; state.Steps++ {
+		var pointer int = state.Pointer
+		instruction, err := state.concrete(pointer, "instruction")
+		if err != nil {
+			return state, err
+		}
+		current_opcode, err := GetOpcode(instruction)
+		if err != nil {
+			return state, &InstructionError{err, pointer, instruction}
+		}
+
+		var number_arg, writing_args int = arguments_of(current_opcode.Code)
+		var values []*Expression = make([]*Expression, 0, number_arg)
+		var target int = 0
+		for arg_index := 0; arg_index < number_arg; arg_index++ {
+			var operand *Expression = state.Cell(pointer + 1 + arg_index)
+			var mode int = current_opcode.Tag(arg_index)
+			var writing bool = arg_index >= number_arg-writing_args
+			if mode == 1 {
+				if writing {
+					return state, &InstructionError{ErrWriteImmediateMode, pointer, instruction}
+				}
+				values = append(values, operand)
+				continue
+			}
+
+			// Reading through an address made of symbols could read anything
+			if operand.Kind != Constant {
+				if writing {
+					return state, fmt.Errorf("%w: address written at ' %d ' is %v", ErrSymbolicControl, pointer, operand)
+				}
+				if mode == 2 {
+					operand = combine(Sum, NewConstant(relative_base), operand)
+				}
+				values = append(values, &Expression{Unknown, 0, "", []*Expression{operand}, operand.Cells})
+				continue
+			}
+
+			var address int = operand.Value
+			if mode == 2 {
+				address = relative_base + address
+			}
+			if address < 0 {
+				return state, &InstructionError{fmt.Errorf("%w: ' %d '", ErrNegativeAddress, address), pointer, instruction}
+			}
+			if writing {
+				target = address
+			} else {
+				values = append(values, state.Cell(address))
+			}
+		}
+
+		var next int = pointer + 1 + number_arg
+		switch current_opcode.Code {
+		case 99:
+			return state, nil
+		case 1:
+			state.memory[target] = combine(Sum, values[0], values[1])
+		case 2:
+			state.memory[target] = combine(Product, values[0], values[1])
+		case 3:
+			state.memory[target] = NewSymbol(fmt.Sprintf("input_%d", state.Inputs))
+			state.Inputs = state.Inputs + 1
+		case 4:
+			state.Output = append(state.Output, values[0])
+		case 5, 6:
+			if values[0].Kind != Constant || values[1].Kind != Constant {
+				return state, fmt.Errorf("%w: jump at ' %d ' on %v to %v", ErrSymbolicControl, pointer, values[0], values[1])
+			}
+			if (values[0].Value != 0) == (current_opcode.Code == 5) {
+				next = values[1].Value
+			}
+		case 7:
+			state.memory[target] = combine(LessThan, values[0], values[1])
+		case 8:
+			state.memory[target] = combine(Equals, values[0], values[1])
+		case 9:
+			if values[0].Kind != Constant {
+				return state, fmt.Errorf("%w: relative base at ' %d ' moved by %v", ErrSymbolicControl, pointer, values[0])
+			}
+			relative_base = relative_base + values[0].Value
+		}
+		state.Pointer = next
+	}
+
+	return state, fmt.Errorf("%w: still running after %d instructions", ErrSymbolicControl, MAX_SYMBOLIC_STEPS)
+}
+
+// concrete reads a cell that has to be known, such as an instruction or an
+// address.
+func (state *SymbolicState) concrete(address int, what string) (int, error) {
+	if address < 0 {
+		return 0, fmt.Errorf("%w: ' %d '", ErrNegativeAddress, address)
+	}
+
+	var value *Expression = state.Cell(address)
+	if value.Kind != Constant {
+		return 0, fmt.Errorf("%w: %s at ' %d ' is %v", ErrSymbolicControl, what, address, value)
+	}
+	return value.Value, nil
+}
+
+// ----------------------- Symbolic Execution End -----------------------
@@ -0,0 +1,111 @@
+package intcode
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// run_noun_verb runs a day 2 program concretely and returns what it left at
+// address 0.
+func run_noun_verb(t *testing.T, codes []int, noun int, verb int) int {
+	var patched []int = make([]int, len(codes))
+	copy(patched, codes)
+	patched[1] = noun
+	patched[2] = verb
+
+	var computer IntCodeComputer = NewFromCodes(patched)
+	err := computer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, _ := computer.ReadMemory(0)
+	return value
+}
+
+func TestSymbolicDay02(t *testing.T) {
+	content, err := os.ReadFile("../day_02/input.txt")
+	if err != nil {
+		t.Skip(err)
+	}
+	codes, err := Parse(string(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := Evaluate(codes, map[int]string{1: "noun", 2: "verb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result *Expression = state.Cell(0)
+	if value, _ := result.Evaluate(map[string]int{"noun": 12, "verb": 2}); value != run_noun_verb(t, codes, 12, 2) {
+		t.Fatalf("evaluated ' %d ', ran ' %d '", value, run_noun_verb(t, codes, 12, 2))
+	}
+
+	linear, is_linear := result.Linear()
+	if !is_linear {
+		t.Fatalf("result ' %v ' is not linear", result)
+	}
+	const TARGET int = 19690720
+	values, found := linear.Solve(TARGET, Range{"noun", 0, 100}, Range{"verb", 0, 100})
+	if !found {
+		t.Fatalf("no solution for %v", linear)
+	}
+
+	// The first pair a search over every noun and verb finds
+	for noun := 0; noun < 100; noun++ {
+		for verb := 0; verb < 100; verb++ {
+			if run_noun_verb(t, codes, noun, verb) != TARGET {
+				continue
+			}
+			if values["noun"] != noun || values["verb"] != verb {
+				t.Fatalf("solved %v, searching found noun ' %d ' verb ' %d '", values, noun, verb)
+			}
+			return
+		}
+	}
+	t.Fatalf("searching found nothing, solved %v", values)
+}
+
+func TestSymbolicNonLinear(t *testing.T) {
+	// Multiplies the cells at 5 and 6 into 0
+	state, err := Evaluate([]int{2, 5, 6, 0, 99, 0, 0}, map[int]string{5: "noun", 6: "verb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result *Expression = state.Cell(0)
+	if _, is_linear := result.Linear(); is_linear {
+		t.Fatalf("product ' %v ' taken as linear", result)
+	}
+	if value, known := result.Evaluate(map[string]int{"noun": 6, "verb": 7}); !known || value != 42 {
+		t.Fatalf("evaluated ' %d ', expected ' 42 '", value)
+	}
+}
+
+func TestLinearSolveUnsolvable(t *testing.T) {
+	// 2 * noun + 3 * verb + 1
+	var linear Linear = Linear{1, map[string]int{"noun": 2, "verb": 3}}
+	var ranges []Range = []Range{{"noun", 0, 10}, {"verb", 0, 10}}
+
+	if values, found := linear.Solve(1+2*4+3*5, ranges...); !found || values["noun"] != 1 || values["verb"] != 7 {
+		t.Fatalf("solved %v, expected noun 1 and verb 7", values)
+	}
+	if values, found := linear.Solve(1000, ranges...); found {
+		t.Fatalf("solved %v out of range", values)
+	}
+	if values, found := linear.Solve(2, ranges...); found {
+		t.Fatalf("solved %v with no integer solution", values)
+	}
+	if values, found := linear.Solve(3, ranges[0]); found {
+		t.Fatalf("solved %v leaving verb unbounded", values)
+	}
+}
+
+func TestSymbolicControl(t *testing.T) {
+	// Jumps on the cell at 1
+	_, err := Evaluate([]int{1005, 1, 0, 99}, map[int]string{1: "noun"})
+	if !errors.Is(err, ErrSymbolicControl) {
+		t.Fatalf("got ' %v ', expected a symbolic jump", err)
+	}
+}
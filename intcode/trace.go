@@ -0,0 +1,231 @@
+package intcode
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ----------------------- Tracer Struct Start -----------------------
+
+// MemoryWrite is a value stored by an instruction.
+type MemoryWrite struct {
+	Address int `json:"address"`
+	Value   int `json:"value"`
+}
+
+// TraceEvent describes one executed instruction. Arguments are resolved: the
+// values read for inputs and the addresses written for outputs.
+type TraceEvent struct {
+	Pointer      int           `json:"ip"`
+	Instruction  int           `json:"instruction"`
+	Opcode       int           `json:"opcode"`
+	Arguments    []int         `json:"arguments"`
+	Writes       []MemoryWrite `json:"writes,omitempty"`
+	RelativeBase int           `json:"rb"`
+	MemorySize   int           `json:"memory"`
+}
+
+// Tracer is told about every instruction the computer executes.
+type Tracer interface {
+	Trace(event TraceEvent)
+}
+
+// SetTracer starts reporting every executed instruction to tracer, or stops
+// when it is nil. Copies made with MakeDeepCopy report to the same tracer.
+func (computer *IntCodeComputer) SetTracer(tracer Tracer) {
+	computer.tracer = tracer
+}
+
+// Tracers reports every event to each of its tracers.
+type Tracers []Tracer
+
+func (tracers Tracers) Trace(event TraceEvent) {
+	for _, tracer := range tracers {
+		tracer.Trace(event)
+	}
+}
+
+// JSONTracer writes the trace as one JSON object per line.
+type JSONTracer struct {
+	encoder *json.Encoder
+	err     error
+}
+
+func NewJSONTracer(writer io.Writer) *JSONTracer {
+	return &JSONTracer{json.NewEncoder(writer), nil}
+}
+
+func (tracer *JSONTracer) Trace(event TraceEvent) {
+	if tracer.err != nil {
+		return
+	}
+	tracer.err = tracer.encoder.Encode(event)
+}
+
+// Err returns the first error met writing the trace.
+func (tracer *JSONTracer) Err() error {
+	return tracer.err
+}
+
+// BinaryTracer writes the trace compactly as signed varints. Each event is its
+// ip, instruction, opcode, rb and memory size, then the count of arguments
+// followed by them, then the count of writes followed by address and value
+// pairs.
+type BinaryTracer struct {
+	writer io.Writer
+	buffer []byte
+	err    error
+}
+
+func NewBinaryTracer(writer io.Writer) *BinaryTracer {
+	return &BinaryTracer{writer, make([]byte, 0, 64), nil}
+}
+
+func (tracer *BinaryTracer) Trace(event TraceEvent) {
+	if tracer.err != nil {
+		return
+	}
+
+	var buffer []byte = tracer.buffer[:0]
+	buffer = binary.AppendVarint(buffer, int64(event.Pointer))
+	buffer = binary.AppendVarint(buffer, int64(event.Instruction))
+	buffer = binary.AppendVarint(buffer, int64(event.Opcode))
+	buffer = binary.AppendVarint(buffer, int64(event.RelativeBase))
+	buffer = binary.AppendVarint(buffer, int64(event.MemorySize))
+	buffer = binary.AppendVarint(buffer, int64(len(event.Arguments)))
+	for _, argument := range event.Arguments {
+		buffer = binary.AppendVarint(buffer, int64(argument))
+	}
+	buffer = binary.AppendVarint(buffer, int64(len(event.Writes)))
+	for _, write := range event.Writes {
+		buffer = binary.AppendVarint(buffer, int64(write.Address))
+		buffer = binary.AppendVarint(buffer, int64(write.Value))
+	}
+
+	tracer.buffer = buffer
+	_, tracer.err = tracer.writer.Write(buffer)
+}
+
+// Err returns the first error met writing the trace.
+func (tracer *BinaryTracer) Err() error {
+	return tracer.err
+}
+
+// ReadBinaryTrace reads back the events written by a BinaryTracer. A trace
+// cut short or holding negative counts fails with ErrTraceInvalid.
+func ReadBinaryTrace(reader io.Reader) ([]TraceEvent, error) {
+	var events []TraceEvent = make([]TraceEvent, 0)
+	var buffered *bufio.Reader = bufio.NewReader(reader)
+
+	var err error = nil
+	var read = func() int {
+		if err != nil {
+			return 0
+		}
+		var value int64
+		value, err = binary.ReadVarint(buffered)
+		return int(value)
+	}
+
+	for {
+		if _, peek_err := buffered.Peek(1); peek_err == io.EOF {
+			return events, nil
+		}
+
+		var event TraceEvent = TraceEvent{read(), read(), read(), nil, nil, read(), read()}
+		var number_arguments int = read()
+		if err == nil && number_arguments < 0 {
+			return nil, fmt.Errorf("%w: ' %d ' arguments", ErrTraceInvalid, number_arguments)
+		}
+		event.Arguments = make([]int, 0)
+		for index := 0; index < number_arguments && err == nil; index++ {
+			event.Arguments = append(event.Arguments, read())
+		}
+
+		var number_writes int = read()
+		if err == nil && number_writes < 0 {
+			return nil, fmt.Errorf("%w: ' %d ' writes", ErrTraceInvalid, number_writes)
+		}
+		for index := 0; index < number_writes && err == nil; index++ {
+			event.Writes = append(event.Writes, MemoryWrite{read(), read()})
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: event %d: %v", ErrTraceInvalid, len(events), err)
+		}
+		events = append(events, event)
+	}
+}
+
+// ----------------------- Tracer Struct End -----------------------
+
+// ----------------------- Profiler Struct Start -----------------------
+
+// Profiler counts where a program spends its instructions.
+type Profiler struct {
+	Instructions    int
+	Opcodes         map[int]int
+	Addresses       map[int]int
+	MemoryHighWater int
+}
+
+func NewProfiler() *Profiler {
+	return &Profiler{0, make(map[int]int), make(map[int]int), 0}
+}
+
+func (profiler *Profiler) Trace(event TraceEvent) {
+	profiler.Instructions = profiler.Instructions + 1
+	profiler.Opcodes[event.Opcode] = profiler.Opcodes[event.Opcode] + 1
+	profiler.Addresses[event.Pointer] = profiler.Addresses[event.Pointer] + 1
+	if event.MemorySize > profiler.MemoryHighWater {
+		profiler.MemoryHighWater = event.MemorySize
+	}
+}
+
+type profile_entry struct {
+	key   int
+	count int
+}
+
+func sorted_counts(counts map[int]int) []profile_entry {
+	var entries []profile_entry = make([]profile_entry, 0, len(counts))
+	for key, count := range counts {
+		entries = append(entries, profile_entry{key, count})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].count != entries[j].count {
+			return entries[i].count > entries[j].count
+		}
+		return entries[i].key < entries[j].key
+	})
+	return entries
+}
+
+// Report writes the totals, the opcode histogram and the top hottest
+// addresses.
+func (profiler *Profiler) Report(writer io.Writer, top int) {
+	fmt.Fprintf(writer, "Instructions executed: %d\n", profiler.Instructions)
+	fmt.Fprintf(writer, "Memory high-water mark: %d cells\n", profiler.MemoryHighWater)
+
+	fmt.Fprintln(writer, "Opcodes:")
+	for _, entry := range sorted_counts(profiler.Opcodes) {
+		var share float64 = 100 * float64(entry.count) / float64(profiler.Instructions)
+		fmt.Fprintf(writer, "  %-4s %12d  %5.1f%%\n", Mnemonics[entry.key], entry.count, share)
+	}
+
+	fmt.Fprintln(writer, "Hot addresses:")
+	for index, entry := range sorted_counts(profiler.Addresses) {
+		if index >= top {
+			break
+		}
+		var share float64 = 100 * float64(entry.count) / float64(profiler.Instructions)
+		fmt.Fprintf(writer, "  %05d %12d  %5.1f%%\n", entry.key, entry.count, share)
+	}
+}
+
+// ----------------------- Profiler Struct End -----------------------
@@ -0,0 +1,122 @@
+package intcode
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// event_log keeps every event it is told about.
+type event_log struct {
+	events []TraceEvent
+}
+
+func (log *event_log) Trace(event TraceEvent) {
+	log.events = append(log.events, event)
+}
+
+// trace_countdown runs the countdown from 3 reporting to the tracers.
+func trace_countdown(t *testing.T, tracers ...Tracer) {
+	codes, err := Assemble(COUNTDOWN_SOURCE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var computer IntCodeComputer = NewFromCodes(codes)
+	computer.AddInput(3)
+	computer.SetTracer(Tracers(tracers))
+	err = computer.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBinaryTraceRoundTrip(t *testing.T) {
+	var log event_log
+	var buffer bytes.Buffer
+	var tracer *BinaryTracer = NewBinaryTracer(&buffer)
+	trace_countdown(t, &log, tracer)
+	if tracer.Err() != nil {
+		t.Fatal(tracer.Err())
+	}
+
+	var encoded []byte = buffer.Bytes()
+	events, err := ReadBinaryTrace(bytes.NewReader(encoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(events, log.events) {
+		t.Fatalf("read back %+v, traced %+v", events, log.events)
+	}
+
+	_, err = ReadBinaryTrace(bytes.NewReader(encoded[:len(encoded)-1]))
+	if !errors.Is(err, ErrTraceInvalid) {
+		t.Fatalf("cut trace read with ' %v '", err)
+	}
+}
+
+func TestJSONTrace(t *testing.T) {
+	var log event_log
+	var buffer bytes.Buffer
+	var tracer *JSONTracer = NewJSONTracer(&buffer)
+	trace_countdown(t, &log, tracer)
+	if tracer.Err() != nil {
+		t.Fatal(tracer.Err())
+	}
+
+	var lines []string = strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != len(log.events) {
+		t.Fatalf("%d lines for %d events", len(lines), len(log.events))
+	}
+	for index, line := range lines {
+		var event TraceEvent
+		err := json.Unmarshal([]byte(line), &event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(event, log.events[index]) {
+			t.Fatalf("line %d is %+v, traced %+v", index+1, event, log.events[index])
+		}
+	}
+
+	// The first event reads 3 into the cell at 12
+	if lines[0] != `{"ip":0,"instruction":3,"opcode":3,"arguments":[12],"writes":[{"address":12,"value":3}],"rb":0,"memory":13}` {
+		t.Fatalf("first line ' %s '", lines[0])
+	}
+}
+
+func TestProfiler(t *testing.T) {
+	var profiler *Profiler = NewProfiler()
+	trace_countdown(t, profiler)
+
+	// The input, three times round the loop and the halt
+	if profiler.Instructions != 11 || profiler.MemoryHighWater != 13 {
+		t.Fatalf("%d instructions reaching %d cells, expected 11 reaching 13", profiler.Instructions, profiler.MemoryHighWater)
+	}
+	if expected := map[int]int{3: 1, 1: 3, 4: 3, 5: 3, 99: 1}; !reflect.DeepEqual(profiler.Opcodes, expected) {
+		t.Fatalf("opcodes %v, expected %v", profiler.Opcodes, expected)
+	}
+	if expected := map[int]int{0: 1, 2: 3, 6: 3, 8: 3, 11: 1}; !reflect.DeepEqual(profiler.Addresses, expected) {
+		t.Fatalf("addresses %v, expected %v", profiler.Addresses, expected)
+	}
+
+	var report bytes.Buffer
+	profiler.Report(&report, 2)
+	var expected string = "Instructions executed: 11\n" +
+		"Memory high-water mark: 13 cells\n" +
+		"Opcodes:\n" +
+		"  ADD             3   27.3%\n" +
+		"  OUT             3   27.3%\n" +
+		"  JNZ             3   27.3%\n" +
+		"  IN              1    9.1%\n" +
+		"  HLT             1    9.1%\n" +
+		"Hot addresses:\n" +
+		"  00002            3   27.3%\n" +
+		"  00006            3   27.3%\n"
+	if report.String() != expected {
+		t.Fatalf("report\n%s\nexpected\n%s", report.String(), expected)
+	}
+}